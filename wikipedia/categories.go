@@ -0,0 +1,66 @@
+package wikipedia
+
+import "context"
+
+// Category represents a category of a wikipedia page.
+type Category struct {
+	Title         string // the category title, with the "Category:" prefix
+	SortKey       string // the hexadecimal sort key of the page in the category
+	SortKeyPrefix string // the human-readable part of the sort key
+	Timestamp     string // the time the page was added to the category
+	Hidden        bool   // whether the category is a hidden maintenance category
+}
+
+type categoriesRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	ClProp        []string `url:"clprop" del:"|" json:"clprop"`
+	ClLimit       string   `url:"cllimit" json:"cllimit"`
+	ClContinue    string   `url:"clcontinue,omitempty" json:"clcontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *categoriesRequest) setContinue(rc responseContinue) bool {
+	r.ClContinue, r.Continue = rc.ClContinue, rc.Continue
+	return len(rc.ClContinue) > 0
+}
+
+// GetPageCategories returns all the categories of the page with the given title,
+// including the hidden ones.
+func (c *Client) GetPageCategories(ctx context.Context, title string) ([]*Category, error) {
+	r := &categoriesRequest{
+		Action:        ActionQuery,
+		Props:         []string{"categories"},
+		Titles:        title,
+		ClProp:        []string{"hidden", "sortkey", "timestamp"},
+		ClLimit:       "max",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	var categories []*Category
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		page, err := response.Query.titlePage(title)
+		if err != nil {
+			return err
+		}
+		for _, cat := range page.Category {
+			categories = append(categories, &Category{
+				Title:         cat.Title,
+				SortKey:       cat.SortKey,
+				SortKeyPrefix: cat.SortKeyPrefix,
+				Timestamp:     cat.Timestamp,
+				Hidden:        bool(cat.Hidden),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,130 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageCategories(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "prop", "info|pageprops") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        }
+    }
+}`)
+			return
+		}
+		if !checkQuery(r.Form, "prop", "categories") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "clprop", "hidden|sortkey|timestamp") {
+			http.Error(w, "invalid clprop", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Form.Get("clcontinue") {
+		case "":
+			fmt.Fprint(w, `
+{
+    "continue": {"clcontinue": "534366|Living_people", "continue": "||"},
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "categories": [
+                    {
+                        "ns": 14,
+                        "title": "Category:Articles with short description",
+                        "sortkey": "4f42414d41",
+                        "sortkeyprefix": "",
+                        "timestamp": "2023-01-01T00:00:00Z",
+                        "hidden": ""
+                    }
+                ]
+            }
+        }
+    }
+}`)
+		case "534366|Living_people":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "categories": [
+                    {
+                        "ns": 14,
+                        "title": "Category:Living people",
+                        "sortkey": "4f42414d41",
+                        "sortkeyprefix": "Obama, Barack",
+                        "timestamp": "2008-01-01T00:00:00Z"
+                    }
+                ]
+            }
+        }
+    }
+}`)
+		default:
+			http.Error(w, "invalid clcontinue", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageCategories(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*Category{
+			{
+				Title:     "Category:Articles with short description",
+				SortKey:   "4f42414d41",
+				Timestamp: "2023-01-01T00:00:00Z",
+				Hidden:    true,
+			},
+			{
+				Title:         "Category:Living people",
+				SortKey:       "4f42414d41",
+				SortKeyPrefix: "Obama, Barack",
+				Timestamp:     "2008-01-01T00:00:00Z",
+			},
+		},
+		got,
+	)
+
+	page, err := c.GetPage(context.TODO(), 534366, WithGetPageCategories(false))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Category:Living people"}, page.Category)
+}
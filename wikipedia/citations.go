@@ -0,0 +1,206 @@
+package wikipedia
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/majdus/go-wikipedia/wikipedia/wikitext"
+)
+
+const maxCitationAuthors = 100
+
+// Citation represents a source cited by a wikipedia page.
+type Citation struct {
+	RefName    string   // the name of the ref defining the citation, if any
+	Template   string   // the citation template, e.g. "cite web", empty for a free-form ref
+	Title      string   // the title of the source
+	Authors    []string // the authors of the source
+	URL        string   // the URL of the source
+	DOI        string   // the DOI of the source
+	ISBN       string   // the ISBN of the source
+	Date       string   // the publication date of the source, as written
+	Publisher  string   // the publisher of the source
+	Work       string   // the work containing the source, e.g. the website, newspaper or journal
+	ArchiveURL string   // the URL of an archived copy of the source
+	Text       string   // the plain text of a free-form ref
+
+	Locations []*CitationLocation // the places the citation is used in the page
+}
+
+// CitationLocation represents a place a citation is used in a wikipedia page.
+type CitationLocation struct {
+	Section string // the title of the enclosing section, empty for the lead section
+	Context string // the plain text of the paragraph or list item citing the source
+}
+
+// GetCitations returns the citations of the page with the given title, from its ref tags and
+// citation templates, in order of first use. A named ref reused several times is returned once
+// with all its locations.
+func (c *Client) GetCitations(ctx context.Context, title string) ([]*Citation, error) {
+	wt, err := c.GetWikitextByTitle(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractCitations(wikitext.Parse(wt.Content)), nil
+}
+
+type citationExtractor struct {
+	citations []*Citation
+	named     map[string]*Citation
+}
+
+func extractCitations(doc *wikitext.Document) []*Citation {
+	e := &citationExtractor{named: make(map[string]*Citation)}
+
+	var section string
+	for _, n := range doc.Nodes {
+		switch v := n.(type) {
+		case *wikitext.Heading:
+			section = wikitext.PlainText(v.Title)
+		case *wikitext.List:
+			for _, item := range v.Items {
+				e.visit(item.Nodes, &CitationLocation{Section: section, Context: wikitext.PlainText(item.Nodes)})
+			}
+		default:
+			e.visit([]wikitext.Node{n}, &CitationLocation{Section: section, Context: wikitext.PlainText([]wikitext.Node{n})})
+		}
+	}
+
+	return e.citations
+}
+
+// visit collects the citations of the nodes, used at the given location. A nil location only
+// collects the named ref definitions, e.g. the list-defined refs of a reflist template.
+func (e *citationExtractor) visit(nodes []wikitext.Node, loc *CitationLocation) {
+	wikitext.Walk(nodes, func(n wikitext.Node) bool {
+		switch v := n.(type) {
+		case *wikitext.Ref:
+			e.ref(v, loc)
+			return false
+		case *wikitext.Template:
+			name := strings.ToLower(v.Name)
+			switch {
+			case name == "reflist" || name == "references":
+				for _, p := range v.Params {
+					e.visit(p.Value, nil)
+				}
+				return false
+			case isCitationTemplate(name) && loc != nil:
+				cite := newCitation(v)
+				cite.Locations = []*CitationLocation{loc}
+				e.citations = append(e.citations, cite)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (e *citationExtractor) ref(ref *wikitext.Ref, loc *CitationLocation) {
+	cites := refCitations(ref)
+
+	if len(ref.Name) == 0 {
+		if loc != nil {
+			for _, cite := range cites {
+				cite.Locations = []*CitationLocation{loc}
+				e.citations = append(e.citations, cite)
+			}
+		}
+		return
+	}
+
+	cite, ok := e.named[ref.Name]
+	if !ok {
+		cite = &Citation{RefName: ref.Name}
+		e.named[ref.Name] = cite
+	}
+	if len(cites) > 0 && len(cite.Template) == 0 && len(cite.Text) == 0 {
+		locations := cite.Locations
+		*cite = *cites[0]
+		cite.RefName, cite.Locations = ref.Name, locations
+	}
+	if loc != nil {
+		if len(cite.Locations) == 0 {
+			e.citations = append(e.citations, cite)
+		}
+		cite.Locations = append(cite.Locations, loc)
+	}
+}
+
+// refCitations returns the citations defined by the content of the ref, several for a bundled ref.
+func refCitations(ref *wikitext.Ref) []*Citation {
+	var cites []*Citation
+	for _, n := range ref.Content {
+		if t, ok := n.(*wikitext.Template); ok && isCitationTemplate(strings.ToLower(t.Name)) {
+			cites = append(cites, newCitation(t))
+		}
+	}
+	if len(cites) > 0 || len(ref.Content) == 0 {
+		return cites
+	}
+
+	cite := &Citation{Text: wikitext.PlainText(ref.Content)}
+	if links := wikitext.FindAll[*wikitext.ExternalLink](ref.Content); len(links) > 0 {
+		cite.URL = links[0].URL
+		cite.Title = wikitext.PlainText(links[0].Text)
+	}
+	return []*Citation{cite}
+}
+
+func isCitationTemplate(name string) bool {
+	return name == "citation" || strings.HasPrefix(name, "cite ")
+}
+
+func newCitation(t *wikitext.Template) *Citation {
+	param := func(names ...string) string {
+		for _, name := range names {
+			if p, ok := t.Param(name); ok {
+				if v := wikitext.PlainText(p.Value); len(v) > 0 {
+					return v
+				}
+			}
+		}
+		return ""
+	}
+
+	return &Citation{
+		Template:   strings.ToLower(t.Name),
+		Title:      param("title", "chapter", "script-title"),
+		Authors:    citationAuthors(param),
+		URL:        param("url", "chapter-url"),
+		DOI:        param("doi"),
+		ISBN:       param("isbn", "isbn13"),
+		Date:       param("date", "year"),
+		Publisher:  param("publisher"),
+		Work:       param("work", "website", "newspaper", "journal", "magazine", "periodical"),
+		ArchiveURL: param("archive-url", "archiveurl"),
+	}
+}
+
+func citationAuthors(param func(names ...string) string) []string {
+	if v := param("vauthors", "authors"); len(v) > 0 {
+		return lo.FilterMap(strings.Split(v, ","), func(a string, _ int) (string, bool) {
+			a = strings.TrimSpace(a)
+			return a, len(a) > 0
+		})
+	}
+
+	var authors []string
+	for i := 0; i <= maxCitationAuthors; i++ {
+		suffix := lo.Ternary(i == 0, "", strconv.Itoa(i))
+		name := param("author" + suffix)
+		if last := param("last"+suffix, "surname"+suffix); len(last) > 0 {
+			name = strings.TrimSpace(param("first"+suffix, "given"+suffix) + " " + last)
+		}
+		if len(name) > 0 {
+			authors = append(authors, name)
+		} else if i > 1 {
+			break
+		}
+	}
+	return authors
+}
@@ -0,0 +1,101 @@
+package wikipedia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetCitations(t *testing.T) {
+	content, err := json.Marshal(`Obama was born in Honolulu.<ref name="birth">{{cite news |last1=Maraniss |first1=David
+ |last2=Smith |first2=Ann |title=Though Obama Had to Leave to Find Himself |url=https://example.org/obama
+ |newspaper=The Washington Post |date=August 24, 2008 |archive-url=https://web.archive.org/obama}}</ref>
+
+== Early life ==
+He studied at Columbia.<ref>[https://example.org/columbia Columbia records], retrieved 2009.</ref><ref name="birth"/>
+
+== Further reading ==
+* {{cite book|author=Barack Obama|title=Dreams from My Father|publisher=Times Books|isbn=978-1-4000-8277-3|year=1995}}
+
+== References ==
+{{reflist|refs=
+<ref name="unused">{{cite journal |vauthors=Smith J, Doe A |title=Unused |doi=10.1000/xyz}}</ref>
+}}`)
+	require.NoError(t, err)
+
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "prop", "revisions") || !checkQuery(r.Form, "titles", "Barack Obama") {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "revisions": [{"revid": 1165884406, "slots": {"main": {"contentmodel": "wikitext", "*": %s}}}]
+            }
+        }
+    }
+}`, content)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetCitations(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*Citation{
+			{
+				RefName:    "birth",
+				Template:   "cite news",
+				Title:      "Though Obama Had to Leave to Find Himself",
+				Authors:    []string{"David Maraniss", "Ann Smith"},
+				URL:        "https://example.org/obama",
+				Date:       "August 24, 2008",
+				Work:       "The Washington Post",
+				ArchiveURL: "https://web.archive.org/obama",
+				Locations: []*CitationLocation{
+					{Context: "Obama was born in Honolulu."},
+					{Section: "Early life", Context: "He studied at Columbia."},
+				},
+			},
+			{
+				Title:     "Columbia records",
+				URL:       "https://example.org/columbia",
+				Text:      "Columbia records, retrieved 2009.",
+				Locations: []*CitationLocation{{Section: "Early life", Context: "He studied at Columbia."}},
+			},
+			{
+				Template:  "cite book",
+				Title:     "Dreams from My Father",
+				Authors:   []string{"Barack Obama"},
+				ISBN:      "978-1-4000-8277-3",
+				Date:      "1995",
+				Publisher: "Times Books",
+				Locations: []*CitationLocation{{Section: "Further reading", Context: ""}},
+			},
+		},
+		got,
+	)
+}
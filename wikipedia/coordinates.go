@@ -0,0 +1,80 @@
+package wikipedia
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+)
+
+// Coordinate represents a geographic coordinate of a wikipedia page.
+type Coordinate struct {
+	Lat     float64 // the latitude in degrees
+	Lon     float64 // the longitude in degrees
+	Globe   string  // the globe the coordinate is on, e.g. "earth" or "moon"
+	Primary bool    // whether it is the primary coordinate of the page
+	Type    string  // the type of the object, e.g. "city" or "landmark"
+	Name    string  // the name of the object, if different from the page title
+	Dim     int     // the approximate size of the object in meters
+	Country string  // the ISO 3166-1 alpha-2 country code
+	Region  string  // the ISO 3166-2 region code, without the country prefix
+}
+
+type coordinatesRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	CoProp        []string `url:"coprop" del:"|" json:"coprop"`
+	CoPrimary     string   `url:"coprimary" json:"coprimary"`
+	CoLimit       string   `url:"colimit" json:"colimit"`
+	CoContinue    string   `url:"cocontinue,omitempty" json:"cocontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *coordinatesRequest) setContinue(rc responseContinue) bool {
+	r.CoContinue, r.Continue = rc.CoContinue, rc.Continue
+	return len(rc.CoContinue) > 0
+}
+
+// GetPageCoordinates returns the coordinates of the page with the given title.
+// The secondary coordinates, e.g. of the places mentioned in the page, are included if secondary is true.
+func (c *Client) GetPageCoordinates(ctx context.Context, title string, secondary bool) ([]*Coordinate, error) {
+	r := &coordinatesRequest{
+		Action:        ActionQuery,
+		Props:         []string{"coordinates"},
+		Titles:        title,
+		CoProp:        []string{"type", "name", "dim", "country", "region", "globe"},
+		CoPrimary:     lo.Ternary(secondary, "all", "primary"),
+		CoLimit:       "max",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	var coordinates []*Coordinate
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		page, err := response.Query.titlePage(title)
+		if err != nil {
+			return err
+		}
+		for _, co := range page.Coordinate {
+			coordinates = append(coordinates, &Coordinate{
+				Lat:     co.Lat,
+				Lon:     co.Lon,
+				Globe:   co.Globe,
+				Primary: bool(co.Primary),
+				Type:    co.Type,
+				Name:    co.Name,
+				Dim:     co.Dim,
+				Country: co.Country,
+				Region:  co.Region,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return coordinates, nil
+}
@@ -0,0 +1,112 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageCoordinates(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "prop", "info|pageprops") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "6886": {"pageid": 6886, "ns": 0, "title": "Chicago", "fullurl": "https://en.wikipedia.org/wiki/Chicago"}
+        }
+    }
+}`)
+			return
+		}
+		if !checkQuery(r.Form, "prop", "coordinates") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "coprop", "type|name|dim|country|region|globe") {
+			http.Error(w, "invalid coprop", http.StatusBadRequest)
+			return
+		}
+		if checkQuery(r.Form, "coprimary", "primary") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "6886": {
+                "pageid": 6886,
+                "ns": 0,
+                "title": "Chicago",
+                "coordinates": [
+                    {
+                        "lat": 41.88194444,
+                        "lon": -87.62777778,
+                        "primary": "",
+                        "type": "city",
+                        "dim": 10000,
+                        "country": "US",
+                        "region": "IL",
+                        "globe": "earth"
+                    }
+                ]
+            }
+        }
+    }
+}`)
+			return
+		}
+		if !checkQuery(r.Form, "coprimary", "all") {
+			http.Error(w, "invalid coprimary", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "6886": {
+                "pageid": 6886,
+                "ns": 0,
+                "title": "Chicago",
+                "coordinates": [
+                    {"lat": 41.88194444, "lon": -87.62777778, "primary": "", "type": "city", "globe": "earth"},
+                    {"lat": 41.8789, "lon": -87.6359, "name": "Willis Tower", "type": "landmark", "globe": "earth"}
+                ]
+            }
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageCoordinates(context.TODO(), "Chicago", true)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*Coordinate{
+			{Lat: 41.88194444, Lon: -87.62777778, Globe: "earth", Primary: true, Type: "city"},
+			{Lat: 41.8789, Lon: -87.6359, Globe: "earth", Type: "landmark", Name: "Willis Tower"},
+		},
+		got,
+	)
+
+	page, err := c.GetPageByTitle(context.TODO(), "Chicago", WithGetPageCoordinates())
+	require.NoError(t, err)
+	require.Equal(t, []float64{41.88194444, -87.62777778}, page.Coordinate)
+}
@@ -0,0 +1,168 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/anaskhan96/soup"
+	"github.com/samber/lo"
+	"golang.org/x/net/html"
+)
+
+// disambiguationSkippedSections are the sections of a disambiguation page not listing options.
+var disambiguationSkippedSections = []string{"see also", "references", "notes", "external links"}
+
+// DisambiguationOption represents a page listed by a disambiguation page.
+type DisambiguationOption struct {
+	Title       string // the title of the listed page
+	Description string // the plain text of the list line, e.g. "Mercury (planet), the closest planet to the Sun"
+	Section     string // the title of the section listing the page, empty for the lead section
+	Exists      bool   // whether the listed page exists, false for a red link
+}
+
+// DisambiguationMode is the handling of disambiguation pages by the Wikipedia page request.
+type DisambiguationMode int
+
+const (
+	DisambiguationResolve DisambiguationMode = iota // fill the options listed by the page, with an extra request
+	DisambiguationFlag                              // flag the page as a disambiguation page, without extra request
+	DisambiguationFail                              // return a *DisambiguationError carrying the listed options
+)
+
+// DisambiguationError is returned for a disambiguation page when the DisambiguationFail mode is set.
+type DisambiguationError struct {
+	Title   string
+	Options []*DisambiguationOption
+}
+
+func (e *DisambiguationError) Error() string {
+	titles := lo.Uniq(lo.Map(e.Options, func(o *DisambiguationOption, _ int) string { return o.Title }))
+	return fmt.Sprintf("go-wikipedia: %q may refer to: %s", e.Title, strings.Join(titles, ", "))
+}
+
+func (e *DisambiguationError) Is(target error) bool {
+	return target == ErrDisambiguation
+}
+
+// disambiguate fills the options listed by the disambiguation page.
+func (c *Client) disambiguate(ctx context.Context, p *Page) error {
+	text, err := c.GetPageHTML(ctx, p.Title, &HTMLOptions{StripEditLinks: true, StripNavboxes: true})
+	if err != nil {
+		return err
+	}
+	if len(text) == 0 {
+		return fmt.Errorf("go-wikipedia: disambiguation page not found")
+	}
+
+	options := disambiguationOptions(text)
+	p.Disambiguation = lo.Uniq(lo.Map(options, func(o *DisambiguationOption, _ int) string { return o.Title }))
+	p.DisambiguationOptions = options
+	return nil
+}
+
+// disambiguationOptions returns the options listed in the main lists of the disambiguation page HTML.
+func disambiguationOptions(text string) []*DisambiguationOption {
+	doc := soup.HTMLParse(text)
+	if doc.Error != nil {
+		return nil
+	}
+
+	root := doc.Find("div", "class", "mw-parser-output")
+	if root.Error != nil {
+		root = doc.Find("body")
+		if root.Error != nil {
+			return nil
+		}
+	}
+
+	var (
+		options []*DisambiguationOption
+		section string
+	)
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for _, child := range elementChildren(n) {
+			switch {
+			case isHeadingElement(child), child.Data == "div" && hasClass(child, "mw-heading"):
+				section = nodeText(child)
+			case lo.Contains(disambiguationSkippedSections, strings.ToLower(section)):
+			case child.Data == "ul" || child.Data == "ol":
+				options = append(options, listOptions(child, section)...)
+			case child.Data == "div" && !hasClass(child, "hatnote") && !hasClass(child, "navbox"):
+				walk(child)
+			}
+		}
+	}
+	walk(root.Pointer)
+
+	return options
+}
+
+func isHeadingElement(n *html.Node) bool {
+	return len(n.Data) == 2 && n.Data[0] == 'h' && n.Data[1] >= '1' && n.Data[1] <= '6'
+}
+
+// listOptions returns the options of the list items, including the nested lists.
+func listOptions(list *html.Node, section string) []*DisambiguationOption {
+	var options []*DisambiguationOption
+	for _, li := range elementChildren(list) {
+		if li.Data != "li" {
+			continue
+		}
+
+		var nested []*html.Node
+		for _, child := range elementChildren(li) {
+			if child.Data == "ul" || child.Data == "ol" {
+				nested = append(nested, child)
+				li.RemoveChild(child)
+			}
+		}
+
+		if a := firstWikiLink(li); a != nil {
+			options = append(options, &DisambiguationOption{
+				Title:       linkTitle(a),
+				Description: nodeText(li),
+				Section:     section,
+				Exists:      !hasClass(a, "new"),
+			})
+		}
+
+		for _, n := range nested {
+			options = append(options, listOptions(n, section)...)
+		}
+	}
+	return options
+}
+
+// firstWikiLink returns the first link of the node to a wiki page, existing or not.
+func firstWikiLink(n *html.Node) *html.Node {
+	for _, a := range (soup.Root{Pointer: n}).FindAll("a") {
+		href := attr(a.Pointer, "href")
+		if strings.HasPrefix(href, "/wiki/") || (strings.HasPrefix(href, "/w/index.php") && hasClass(a.Pointer, "new")) {
+			return a.Pointer
+		}
+	}
+	return nil
+}
+
+// linkTitle returns the title of the page linked by the anchor.
+func linkTitle(a *html.Node) string {
+	href := attr(a, "href")
+	if strings.HasPrefix(href, "/w/index.php") {
+		if u, err := url.Parse(href); err == nil {
+			if t := u.Query().Get("title"); len(t) > 0 {
+				return strings.ReplaceAll(t, "_", " ")
+			}
+		}
+	}
+	if t := attr(a, "title"); len(t) > 0 {
+		return t
+	}
+	t, err := url.PathUnescape(strings.TrimPrefix(href, "/wiki/"))
+	if err != nil {
+		t = strings.TrimPrefix(href, "/wiki/")
+	}
+	return strings.ReplaceAll(t, "_", " ")
+}
@@ -0,0 +1,152 @@
+package wikipedia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+const mercuryDisambiguationHTML = `<div class="mw-parser-output">
+<p><b>Mercury</b> may refer to:</p>
+<div class="hatnote">For the element, see <a href="/wiki/Mercury_(element)" title="Mercury (element)">Mercury</a>.</div>
+<div class="mw-heading mw-heading2"><h2 id="Astronomy">Astronomy</h2></div>
+<ul>
+<li><a href="/wiki/Mercury_(planet)" title="Mercury (planet)">Mercury (planet)</a>, the closest planet to the Sun
+<ul><li><a href="/wiki/Transit_of_Mercury" title="Transit of Mercury">Transit of Mercury</a></li></ul></li>
+</ul>
+<h2>Music</h2>
+<div class="div-col"><ul>
+<li><a href="/w/index.php?title=Mercury_(band)&amp;action=edit&amp;redlink=1" class="new"
+title="Mercury (band) (page does not exist)">Mercury (band)</a>, a fictional band</li>
+<li>An unlinked line</li>
+</ul></div>
+<h2>See also</h2>
+<ul><li><a href="/wiki/Hermes" title="Hermes">Hermes</a></li></ul>
+</div>`
+
+func newDisambiguationTestServer(t *testing.T, parsed *bool) *testhelper.TestHTTPServer {
+	text, err := json.Marshal(mercuryDisambiguationHTML)
+	require.NoError(t, err)
+
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "action", "parse") {
+			*parsed = true
+			fmt.Fprintf(w, `{"parse": {"title": "Mercury", "pageid": 19694, "text": {"*": %s}}}`, text)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "19694": {
+                "pageid": 19694,
+                "ns": 0,
+                "title": "Mercury",
+                "fullurl": "https://en.wikipedia.org/wiki/Mercury",
+                "pageprops": {"disambiguation": ""}
+            }
+        }
+    }
+}`)
+	})
+	return ts
+}
+
+var mercuryDisambiguationOptions = []*DisambiguationOption{
+	{
+		Title:       "Mercury (planet)",
+		Description: "Mercury (planet), the closest planet to the Sun",
+		Section:     "Astronomy",
+		Exists:      true,
+	},
+	{Title: "Transit of Mercury", Description: "Transit of Mercury", Section: "Astronomy", Exists: true},
+	{Title: "Mercury (band)", Description: "Mercury (band), a fictional band", Section: "Music"},
+}
+
+func TestClient_GetPageDisambiguation(t *testing.T) {
+	var parsed bool
+	ts := newDisambiguationTestServer(t, &parsed)
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageByTitle(context.TODO(), "Mercury")
+	require.NoError(t, err)
+	require.True(t, parsed)
+	require.Equal(
+		t,
+		&Page{
+			PageID:                19694,
+			Title:                 "Mercury",
+			URL:                   "https://en.wikipedia.org/wiki/Mercury",
+			Disambiguation:        []string{"Mercury (planet)", "Transit of Mercury", "Mercury (band)"},
+			IsDisambiguation:      true,
+			DisambiguationOptions: mercuryDisambiguationOptions,
+		},
+		got,
+	)
+}
+
+func TestClient_GetPageDisambiguationFlag(t *testing.T) {
+	var parsed bool
+	ts := newDisambiguationTestServer(t, &parsed)
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageByTitle(context.TODO(), "Mercury", WithGetPageDisambiguation(DisambiguationFlag))
+	require.NoError(t, err)
+	require.False(t, parsed)
+	require.Equal(
+		t,
+		&Page{
+			PageID:           19694,
+			Title:            "Mercury",
+			URL:              "https://en.wikipedia.org/wiki/Mercury",
+			IsDisambiguation: true,
+		},
+		got,
+	)
+}
+
+func TestClient_GetPageDisambiguationFail(t *testing.T) {
+	var parsed bool
+	ts := newDisambiguationTestServer(t, &parsed)
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	_, err = c.GetPageByTitle(context.TODO(), "Mercury", WithGetPageDisambiguation(DisambiguationFail))
+
+	require.ErrorIs(t, err, ErrDisambiguation)
+
+	var de *DisambiguationError
+	require.ErrorAs(t, err, &de)
+	require.Equal(t, "Mercury", de.Title)
+	require.Equal(t, mercuryDisambiguationOptions, de.Options)
+	require.EqualError(
+		t,
+		err,
+		`go-wikipedia: "Mercury" may refer to: Mercury (planet), Transit of Mercury, Mercury (band)`,
+	)
+}
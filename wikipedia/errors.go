@@ -0,0 +1,117 @@
+package wikipedia
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrPageNotFound is returned for a requested page that does not exist.
+	ErrPageNotFound = errors.New("go-wikipedia: page not found")
+	// ErrRedirect is returned for a requested page that is a redirect not followed.
+	ErrRedirect = errors.New("go-wikipedia: page is a redirect")
+	// ErrDisambiguation is returned for a requested disambiguation page when the DisambiguationFail mode is set.
+	ErrDisambiguation = errors.New("go-wikipedia: page is a disambiguation page")
+	// ErrEmptyQuery is returned for a search with an empty query.
+	ErrEmptyQuery = errors.New("go-wikipedia: query is empty")
+)
+
+// APIError is returned for a request failed by the API, either with an error of the API
+// response or with an unexpected HTTP status.
+type APIError struct {
+	Code       string // the error code of the API, e.g. "badvalue", empty for an HTTP error
+	Info       string // the error description of the API
+	StatusCode int    // the HTTP status code of the response
+	RequestID  string // the request id of the response, to report to the API maintainers
+}
+
+func (e *APIError) Error() string {
+	if len(e.Code) == 0 {
+		return fmt.Sprintf("go-wikipedia: http request: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("go-wikipedia: returns error, code: %s, info: %s", e.Code, e.Info)
+}
+
+// Is reports the errors of the API for a missing page, e.g. from the parse action, as ErrPageNotFound.
+func (e *APIError) Is(target error) bool {
+	switch e.Code {
+	case "missingtitle", "nosuchpageid", "nosuchrevid":
+		return target == ErrPageNotFound
+	}
+	return false
+}
+
+// MissingPageError is returned for a requested page that does not exist.
+type MissingPageError struct {
+	ID    int    // the requested page id, zero for a page requested by title
+	Title string // the requested page title, empty for a page requested by id
+}
+
+func (e *MissingPageError) Error() string {
+	if len(e.Title) > 0 {
+		return fmt.Sprintf("go-wikipedia: page not found: %q", e.Title)
+	}
+	return fmt.Sprintf("go-wikipedia: page not found: %d", e.ID)
+}
+
+func (e *MissingPageError) Is(target error) bool {
+	return target == ErrPageNotFound
+}
+
+// MissingRevisionError is returned for a requested revision that does not exist, or for a page
+// returned without revision.
+type MissingRevisionError struct {
+	RevID int    // the requested revision id, zero for the current revision of a page
+	Title string // the title of the page returned without revision, empty for a requested revision id
+}
+
+func (e *MissingRevisionError) Error() string {
+	if e.RevID > 0 {
+		return fmt.Sprintf("go-wikipedia: revision not found: %d", e.RevID)
+	}
+	return fmt.Sprintf("go-wikipedia: revision not found for page: %q", e.Title)
+}
+
+func (e *MissingRevisionError) Is(target error) bool {
+	return target == ErrPageNotFound
+}
+
+// InvalidTitleError is returned for a requested title that is not a valid page title.
+type InvalidTitleError struct {
+	Title  string
+	Reason string // the reason given by the API, e.g. the title contains invalid characters
+}
+
+func (e *InvalidTitleError) Error() string {
+	return fmt.Sprintf("go-wikipedia: invalid title %q: %s", e.Title, e.Reason)
+}
+
+// SpecialPageError is returned for a requested page of the Special or Media namespaces,
+// which have no content to return.
+type SpecialPageError struct {
+	Title string
+}
+
+func (e *SpecialPageError) Error() string {
+	return fmt.Sprintf("go-wikipedia: special page: %q", e.Title)
+}
+
+// RedirectError is returned for a requested title whose redirects loop or are too many to follow.
+type RedirectError struct {
+	Path         []string // the titles followed from the requested title
+	Loop         bool     // whether the redirects loop back to a title of the path
+	MaxRedirects int      // the max number of redirects followed
+}
+
+func (e *RedirectError) Error() string {
+	if e.Loop {
+		return fmt.Sprintf("go-wikipedia: redirect loop: %s", strings.Join(e.Path, " -> "))
+	}
+	return fmt.Sprintf("go-wikipedia: too many redirects from %q, max %d", e.Path[0], e.MaxRedirects)
+}
+
+func (e *RedirectError) Is(target error) bool {
+	return target == ErrRedirect
+}
@@ -0,0 +1,125 @@
+package wikipedia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_Errors(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		w.Header().Set("X-Request-Id", "6a1f3c2e-request")
+		if checkQuery(r.Form, "action", "parse") {
+			fmt.Fprint(w, `
+{
+    "error": {"code": "missingtitle", "info": "The page you specified doesn't exist."},
+    "servedby": "mw1234"
+}`)
+			return
+		}
+		if checkQuery(r.Form, "revids", "1") {
+			fmt.Fprint(w, `{"batchcomplete": true, "query": {"badrevids": {"1": {"revid": 1, "missing": true}}}}`)
+			return
+		}
+		switch r.Form.Get("titles") {
+		case "Not a page":
+			fmt.Fprint(w, `{"batchcomplete": true, "query": {"pages": [{"ns": 0, "title": "Not a page", "missing": true}]}}`)
+		case "Obama":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {"pages": [{"pageid": 7404, "ns": 0, "title": "Obama", "redirect": true}]}
+}`)
+		case "Loop":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {
+        "redirects": [{"from": "Loop", "to": "Cycle"}, {"from": "Cycle", "to": "Loop"}],
+        "pages": [{"pageid": 7405, "ns": 0, "title": "Loop", "redirect": true}]
+    }
+}`)
+		case "Bad request":
+			fmt.Fprint(w, `
+{
+    "error": {
+        "code": "badvalue",
+        "info": "Unrecognized value for parameter \"prop\": foo.",
+        "*": "See https://en.wikipedia.org/w/api.php for API usage."
+    },
+    "servedby": "mw1234"
+}`)
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+
+	_, err = c.GetPageByTitle(context.TODO(), "Not a page")
+	require.ErrorIs(t, err, ErrPageNotFound)
+
+	_, err = c.GetPageHTML(context.TODO(), "Not a page", nil)
+	require.ErrorIs(t, err, ErrPageNotFound)
+	require.ErrorAs(t, err, new(*APIError))
+
+	_, err = c.GetWikitextByRevision(context.TODO(), 1)
+	require.ErrorIs(t, err, ErrPageNotFound)
+	var revisionErr *MissingRevisionError
+	require.ErrorAs(t, err, &revisionErr)
+	require.Equal(t, &MissingRevisionError{RevID: 1}, revisionErr)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Obama")
+	require.ErrorIs(t, err, ErrRedirect)
+	require.EqualError(t, err, "go-wikipedia: page is a redirect, set Redirects option to true to follow redirects")
+
+	_, err = c.GetPageByTitle(context.TODO(), "Loop", WithGetPageRedirects(true))
+	require.ErrorIs(t, err, ErrRedirect)
+	var redirectErr *RedirectError
+	require.ErrorAs(t, err, &redirectErr)
+	require.Equal(t, &RedirectError{Path: []string{"Loop", "Cycle", "Loop"}, Loop: true, MaxRedirects: 10}, redirectErr)
+	require.EqualError(t, err, "go-wikipedia: redirect loop: Loop -> Cycle -> Loop")
+
+	_, err = c.GetPageByTitle(context.TODO(), "Loop", WithGetPageRedirects(true), WithGetPageMaxRedirects(1))
+	require.ErrorAs(t, err, &redirectErr)
+	require.Equal(t, &RedirectError{Path: []string{"Loop", "Cycle"}, MaxRedirects: 1}, redirectErr)
+	require.EqualError(t, err, `go-wikipedia: too many redirects from "Loop", max 1`)
+
+	_, err = c.Search(context.TODO(), "", nil)
+	require.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Bad request")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(
+		t,
+		&APIError{
+			Code:       "badvalue",
+			Info:       `Unrecognized value for parameter "prop": foo.`,
+			StatusCode: http.StatusOK,
+			RequestID:  "6a1f3c2e-request",
+		},
+		apiErr,
+	)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Unavailable")
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, &APIError{StatusCode: http.StatusServiceUnavailable, RequestID: "6a1f3c2e-request"}, apiErr)
+	require.EqualError(t, err, "go-wikipedia: http request: 503 Service Unavailable")
+}
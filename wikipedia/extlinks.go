@@ -0,0 +1,66 @@
+package wikipedia
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+)
+
+// ExternalLinksOptions are the options for the Wikipedia external links request.
+type ExternalLinksOptions struct {
+	Protocol string // the protocol of the links returned, e.g. "https", default all protocols
+	Query    string // the search string without protocol the links must match, e.g. "*.nytimes.com"
+}
+
+type extlinksRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	ElProtocol    string   `url:"elprotocol,omitempty" json:"elprotocol"`
+	ElQuery       string   `url:"elquery,omitempty" json:"elquery"`
+	ElLimit       string   `url:"ellimit" json:"ellimit"`
+	ElContinue    string   `url:"elcontinue,omitempty" json:"elcontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *extlinksRequest) setContinue(rc responseContinue) bool {
+	r.ElContinue, r.Continue = string(rc.ElContinue), rc.Continue
+	return len(rc.ElContinue) > 0
+}
+
+// GetPageExternalLinks returns the URLs of all the external links of the page with the given title.
+func (c *Client) GetPageExternalLinks(ctx context.Context, title string, opts *ExternalLinksOptions) ([]string, error) {
+	if opts == nil {
+		opts = &ExternalLinksOptions{}
+	}
+
+	r := &extlinksRequest{
+		Action:        ActionQuery,
+		Props:         []string{"extlinks"},
+		Titles:        title,
+		ElProtocol:    opts.Protocol,
+		ElQuery:       opts.Query,
+		ElLimit:       "max",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	var urls []string
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		page, err := response.Query.titlePage(title)
+		if err != nil {
+			return err
+		}
+		for _, l := range page.Extlink {
+			urls = append(urls, lo.Ternary(len(l.URL) > 0, l.URL, l.Star))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
@@ -0,0 +1,94 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageExternalLinks(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "prop", "extlinks") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "elprotocol", "https") {
+			http.Error(w, "invalid elprotocol", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "elquery", "*.nytimes.com") {
+			http.Error(w, "invalid elquery", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Form.Get("elcontinue") {
+		case "":
+			fmt.Fprint(w, `
+{
+    "continue": {"elcontinue": 1, "continue": "||"},
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "extlinks": [
+                    {"*": "https://www.nytimes.com/2008/11/05/us/politics/05obama.html"}
+                ]
+            }
+        }
+    }
+}`)
+		case "1":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "extlinks": [
+                    {"*": "https://www.nytimes.com/2009/01/21/us/politics/20web-inaug2.html"}
+                ]
+            }
+        }
+    }
+}`)
+		default:
+			http.Error(w, "invalid elcontinue", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageExternalLinks(
+		context.TODO(),
+		"Barack Obama",
+		&ExternalLinksOptions{Protocol: "https", Query: "*.nytimes.com"},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{
+			"https://www.nytimes.com/2008/11/05/us/politics/05obama.html",
+			"https://www.nytimes.com/2009/01/21/us/politics/20web-inaug2.html",
+		},
+		got,
+	)
+}
@@ -0,0 +1,69 @@
+package wikipedia
+
+import "context"
+
+// ExtractSectionFormat is the format of the section headings in a plain text extract.
+type ExtractSectionFormat string
+
+const (
+	ExtractSectionFormatPlain ExtractSectionFormat = "plain" // no formatting
+	ExtractSectionFormatWiki  ExtractSectionFormat = "wiki"  // wikitext-style formatting, e.g. "== Heading =="
+	ExtractSectionFormatRaw   ExtractSectionFormat = "raw"   // raw markers to be replaced by the caller
+)
+
+// ExtractOptions are the options for the Wikipedia page content extract.
+type ExtractOptions struct {
+	PlainText     bool                 // return plain text instead of limited HTML, default false
+	SectionFormat ExtractSectionFormat // the format of the section headings in plain text, default wiki
+	Sentences     int                  // the max number of sentences returned, default the whole page
+	Chars         int                  // the max number of characters returned, default the whole page
+	IntroOnly     bool                 // return only the lead section, default false
+}
+
+// SummaryOptions are the options for the Wikipedia summary request.
+type SummaryOptions struct {
+	Sentences int // the max number of sentences returned, default the whole lead section
+	Chars     int // the max number of characters returned, default the whole lead section
+}
+
+type extractsRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	ExIntro       bool     `url:"exintro,omitempty" json:"exintro"`
+	ExPlainText   bool     `url:"explaintext,omitempty" json:"explaintext"`
+	ExSentences   int      `url:"exsentences,omitempty" json:"exsentences"`
+	ExChars       int      `url:"exchars,omitempty" json:"exchars"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+// GetSummary returns the plain text lead section of the page with the given title.
+func (c *Client) GetSummary(ctx context.Context, title string, opts *SummaryOptions) (string, error) {
+	if opts == nil {
+		opts = &SummaryOptions{}
+	}
+
+	r := &extractsRequest{
+		Action:        ActionQuery,
+		Props:         []string{"extracts"},
+		Titles:        title,
+		ExIntro:       true,
+		ExPlainText:   true,
+		ExSentences:   opts.Sentences,
+		ExChars:       opts.Chars,
+		Format:        "json",
+		FormatVersion: 2,
+	}
+	response, err := c.do(ctx, r)
+	if err != nil {
+		return "", err
+	}
+
+	page, err := response.Query.titlePage(title)
+	if err != nil {
+		return "", err
+	}
+
+	return page.Extract, nil
+}
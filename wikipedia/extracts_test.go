@@ -0,0 +1,83 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetSummary(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "prop", "info|pageprops") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        }
+    }
+}`)
+			return
+		}
+		if !checkQuery(r.Form, "prop", "extracts") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "exintro", "true") || !checkQuery(r.Form, "explaintext", "true") {
+			http.Error(w, "invalid extract mode", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "exsentences", "1") {
+			http.Error(w, "invalid exsentences", http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.Form["exchars"]; ok {
+			http.Error(w, "unexpected exchars", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "extract": "Barack Hussein Obama II is an American politician who served as the 44th president."
+            }
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetSummary(context.TODO(), "Barack Obama", &SummaryOptions{Sentences: 1})
+	require.NoError(t, err)
+	require.Equal(t, "Barack Hussein Obama II is an American politician who served as the 44th president.", got)
+
+	page, err := c.GetPage(context.TODO(), 534366, WithGetPageSummary(&SummaryOptions{Sentences: 1}))
+	require.NoError(t, err)
+	require.Equal(t, "Barack Hussein Obama II is an American politician who served as the 44th president.", page.Summary)
+}
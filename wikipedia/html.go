@@ -0,0 +1,90 @@
+package wikipedia
+
+import (
+	"context"
+	"strings"
+
+	"github.com/anaskhan96/soup"
+)
+
+// HTMLOptions are the options for the Wikipedia page HTML request.
+type HTMLOptions struct {
+	StripEditLinks  bool // remove the "[edit]" links of the section headings
+	StripNavboxes   bool // remove the navigation boxes
+	StripReferences bool // remove the reference lists
+}
+
+var (
+	navboxClasses    = []string{"navbox", "vertical-navbox"}
+	referenceClasses = []string{"reflist", "references", "mw-references-wrap"}
+)
+
+type pageHTMLRequest struct {
+	Action             Action   `url:"action" json:"action"`
+	Page               string   `url:"page" json:"page"`
+	Props              []string `url:"prop" del:"|" json:"prop"`
+	DisableEditSection bool     `url:"disableeditsection,omitempty" json:"disableeditsection"`
+	DisableLimitReport bool     `url:"disablelimitreport" json:"disablelimitreport"`
+	Format             string   `url:"format"`
+}
+
+// GetPageHTML returns the rendered HTML of the page with the given title.
+func (c *Client) GetPageHTML(ctx context.Context, title string, opts *HTMLOptions) (string, error) {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+
+	r := &pageHTMLRequest{
+		Action:             ActionParse,
+		Page:               title,
+		Props:              []string{"text"},
+		DisableEditSection: opts.StripEditLinks,
+		DisableLimitReport: true,
+		Format:             "json",
+	}
+	response, err := c.do(ctx, r)
+	if err != nil {
+		return "", err
+	}
+
+	text := string(response.Parse.Text)
+	if !opts.StripNavboxes && !opts.StripReferences {
+		return text, nil
+	}
+
+	var classes []string
+	if opts.StripNavboxes {
+		classes = append(classes, navboxClasses...)
+	}
+	if opts.StripReferences {
+		classes = append(classes, referenceClasses...)
+	}
+	return stripHTML(text, classes), nil
+}
+
+// stripHTML removes the elements having any of the given classes from the HTML fragment.
+func stripHTML(text string, classes []string) string {
+	doc := soup.HTMLParse(text)
+	if doc.Error != nil {
+		return text
+	}
+
+	for _, class := range classes {
+		for _, n := range doc.FindAll("", "class", class) {
+			if n.Pointer.Parent != nil {
+				n.Pointer.Parent.RemoveChild(n.Pointer)
+			}
+		}
+	}
+
+	body := doc.Find("body")
+	if body.Error != nil {
+		return text
+	}
+
+	var b strings.Builder
+	for _, child := range body.Children() {
+		b.WriteString(child.HTML())
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageHTML(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "action", "query") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        }
+    }
+}`)
+			return
+		}
+		if !checkQuery(r.Form, "action", "parse") || !checkQuery(r.Form, "prop", "text") {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "disableeditsection", "true") {
+			http.Error(w, "invalid disableeditsection", http.StatusBadRequest)
+			return
+		}
+		text := `<div class="mw-parser-output"><p>Barack Obama.</p>` +
+			`<div class="reflist"><ol class="references"><li>Ref</li></ol></div>` +
+			`<div role="navigation" class="navbox">Nav</div></div>`
+		fmt.Fprintf(w, `
+{
+    "parse": {
+        "title": "Barack Obama",
+        "pageid": 534366,
+        "text": {
+            "*": %q
+        }
+    }
+}`, text)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageHTML(context.TODO(), "Barack Obama", &HTMLOptions{StripEditLinks: true, StripNavboxes: true})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`<div class="mw-parser-output"><p>Barack Obama.</p>`+
+			`<div class="reflist"><ol class="references"><li>Ref</li></ol></div></div>`,
+		got,
+	)
+
+	page, err := c.GetPageByTitle(context.TODO(), "Barack Obama", WithGetPageHTML(&HTMLOptions{
+		StripEditLinks:  true,
+		StripNavboxes:   true,
+		StripReferences: true,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, `<div class="mw-parser-output"><p>Barack Obama.</p></div>`, page.HTML)
+}
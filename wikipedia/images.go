@@ -0,0 +1,172 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Image represents a file used on a wikipedia page.
+type Image struct {
+	Title               string // the file title, with the "File:" prefix
+	URL                 string // the URL of the original file
+	DescriptionURL      string // the URL of the file description page
+	ThumbURL            string // the URL of the thumbnail, if a width was requested
+	ThumbWidth          int    // the width of the thumbnail
+	ThumbHeight         int    // the height of the thumbnail
+	Mime                string // the MIME type of the file
+	Width               int    // the width of the original file
+	Height              int    // the height of the original file
+	Size                int    // the size of the original file in bytes
+	License             string // the short name of the license, e.g. "CC BY-SA 4.0"
+	LicenseURL          string // the URL of the license
+	Artist              string // the author of the file, may contain HTML
+	Credit              string // the source of the file, may contain HTML
+	AttributionRequired bool   // whether the license requires attribution
+}
+
+type imagesRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	ImLimit       string   `url:"imlimit" json:"imlimit"`
+	ImContinue    string   `url:"imcontinue,omitempty" json:"imcontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *imagesRequest) setContinue(rc responseContinue) bool {
+	r.ImContinue, r.Continue = rc.ImContinue, rc.Continue
+	return len(rc.ImContinue) > 0
+}
+
+type imageInfoRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        []string `url:"titles" del:"|" json:"titles"`
+	IiProp        []string `url:"iiprop" del:"|" json:"iiprop"`
+	IiURLWidth    int      `url:"iiurlwidth,omitempty" json:"iiurlwidth"`
+	IiContinue    string   `url:"iicontinue,omitempty" json:"iicontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *imageInfoRequest) setContinue(rc responseContinue) bool {
+	r.IiContinue, r.Continue = rc.IiContinue, rc.Continue
+	return len(rc.IiContinue) > 0
+}
+
+// GetPageImages returns all the files used on the page with the given title, resolved to
+// their URLs and metadata. A thumbnail URL of the given width is resolved if width is positive.
+func (c *Client) GetPageImages(ctx context.Context, title string, width int) ([]*Image, error) {
+	titles, err := c.pageImageTitles(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]*Image, 0, len(titles))
+	for _, chunk := range lo.Chunk(titles, maxTitles) {
+		infos, err := c.imageInfos(ctx, chunk, width)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range chunk {
+			if img, ok := infos[t]; ok {
+				images = append(images, img)
+			}
+		}
+	}
+
+	return images, nil
+}
+
+func (c *Client) pageImageTitles(ctx context.Context, title string) ([]string, error) {
+	r := &imagesRequest{
+		Action:        ActionQuery,
+		Props:         []string{"images"},
+		Titles:        title,
+		ImLimit:       "max",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	var titles []string
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		page, err := response.Query.titlePage(title)
+		if err != nil {
+			return err
+		}
+		for _, img := range page.Images {
+			titles = append(titles, img.Title)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return titles, nil
+}
+
+func (c *Client) imageInfos(ctx context.Context, titles []string, width int) (map[string]*Image, error) {
+	r := &imageInfoRequest{
+		Action:        ActionQuery,
+		Props:         []string{"imageinfo"},
+		Titles:        titles,
+		IiProp:        []string{"url", "mime", "size", "extmetadata"},
+		IiURLWidth:    width,
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	images := make(map[string]*Image, len(titles))
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		// files hosted on a shared repository are reported missing but still carry their info
+		for _, page := range response.Query.Pages {
+			if len(page.ImageInfo) > 0 {
+				images[page.Title] = newImage(page.Title, &page.ImageInfo[0])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func newImage(title string, info *imageInfo) *Image {
+	return &Image{
+		Title:               title,
+		URL:                 info.URL,
+		DescriptionURL:      info.DescriptionURL,
+		ThumbURL:            info.ThumbURL,
+		ThumbWidth:          info.ThumbWidth,
+		ThumbHeight:         info.ThumbHeight,
+		Mime:                info.Mime,
+		Width:               info.Width,
+		Height:              info.Height,
+		Size:                info.Size,
+		License:             info.metadata("LicenseShortName"),
+		LicenseURL:          info.metadata("LicenseUrl"),
+		Artist:              info.metadata("Artist"),
+		Credit:              info.metadata("Credit"),
+		AttributionRequired: strings.EqualFold(info.metadata("AttributionRequired"), "true"),
+	}
+}
+
+func (i *imageInfo) metadata(key string) string {
+	m, ok := i.ExtMetadata[key]
+	if !ok || m.Value == nil {
+		return ""
+	}
+	if s, ok := m.Value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(m.Value)
+}
@@ -0,0 +1,157 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageImages(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		switch r.Form.Get("prop") {
+		case "images":
+			if !checkQuery(r.Form, "titles", "Barack Obama") {
+				http.Error(w, "invalid titles", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "images": [
+                    {"ns": 6, "title": "File:Obama signature.svg"},
+                    {"ns": 6, "title": "File:President Barack Obama.jpg"}
+                ]
+            }
+        }
+    }
+}`)
+		case "imageinfo":
+			if !checkQuery(r.Form, "titles", "File:Obama signature.svg|File:President Barack Obama.jpg") {
+				http.Error(w, "invalid titles", http.StatusBadRequest)
+				return
+			}
+			if !checkQuery(r.Form, "iiprop", "url|mime|size|extmetadata") {
+				http.Error(w, "invalid iiprop", http.StatusBadRequest)
+				return
+			}
+			if !checkQuery(r.Form, "iiurlwidth", "320") {
+				http.Error(w, "invalid iiurlwidth", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "-1": {
+                "ns": 6,
+                "title": "File:President Barack Obama.jpg",
+                "missing": "",
+                "known": "",
+                "imagerepository": "shared",
+                "imageinfo": [
+                    {
+                        "size": 2173495,
+                        "width": 2400,
+                        "height": 3000,
+                        "thumburl": "https://upload.wikimedia.org/thumb/President_Barack_Obama.jpg/320px.jpg",
+                        "thumbwidth": 320,
+                        "thumbheight": 400,
+                        "url": "https://upload.wikimedia.org/President_Barack_Obama.jpg",
+                        "descriptionurl": "https://commons.wikimedia.org/wiki/File:President_Barack_Obama.jpg",
+                        "mime": "image/jpeg",
+                        "extmetadata": {
+                            "LicenseShortName": {"value": "Public domain", "source": "commons-desc-page"},
+                            "Artist": {"value": "Pete Souza", "source": "commons-desc-page"},
+                            "AttributionRequired": {"value": "false", "source": "commons-desc-page"}
+                        }
+                    }
+                ]
+            },
+            "-2": {
+                "ns": 6,
+                "title": "File:Obama signature.svg",
+                "missing": "",
+                "known": "",
+                "imagerepository": "shared",
+                "imageinfo": [
+                    {
+                        "size": 5012,
+                        "width": 512,
+                        "height": 256,
+                        "url": "https://upload.wikimedia.org/Obama_signature.svg",
+                        "descriptionurl": "https://commons.wikimedia.org/wiki/File:Obama_signature.svg",
+                        "mime": "image/svg+xml",
+                        "extmetadata": {
+                            "LicenseShortName": {"value": "CC BY-SA 4.0", "source": "commons-desc-page"},
+                            "LicenseUrl": {"value": "https://creativecommons.org/licenses/by-sa/4.0", "source": "x"},
+                            "AttributionRequired": {"value": "true", "source": "commons-desc-page"}
+                        }
+                    }
+                ]
+            }
+        }
+    }
+}`)
+		default:
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageImages(context.TODO(), "Barack Obama", 320)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*Image{
+			{
+				Title:               "File:Obama signature.svg",
+				URL:                 "https://upload.wikimedia.org/Obama_signature.svg",
+				DescriptionURL:      "https://commons.wikimedia.org/wiki/File:Obama_signature.svg",
+				Mime:                "image/svg+xml",
+				Width:               512,
+				Height:              256,
+				Size:                5012,
+				License:             "CC BY-SA 4.0",
+				LicenseURL:          "https://creativecommons.org/licenses/by-sa/4.0",
+				AttributionRequired: true,
+			},
+			{
+				Title:          "File:President Barack Obama.jpg",
+				URL:            "https://upload.wikimedia.org/President_Barack_Obama.jpg",
+				DescriptionURL: "https://commons.wikimedia.org/wiki/File:President_Barack_Obama.jpg",
+				ThumbURL:       "https://upload.wikimedia.org/thumb/President_Barack_Obama.jpg/320px.jpg",
+				ThumbWidth:     320,
+				ThumbHeight:    400,
+				Mime:           "image/jpeg",
+				Width:          2400,
+				Height:         3000,
+				Size:           2173495,
+				License:        "Public domain",
+				Artist:         "Pete Souza",
+			},
+		},
+		got,
+	)
+}
@@ -0,0 +1,99 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/majdus/go-wikipedia/wikipedia/wikitext"
+)
+
+var (
+	breakRegexp = regexp.MustCompile(`(?i)<br\s*/?>`)
+	spaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+// Infobox represents the infobox template of a wikipedia page.
+type Infobox struct {
+	Name   string          // the template name, e.g. "Infobox officeholder"
+	Fields []*InfoboxField // the template parameters in order
+}
+
+// InfoboxField represents a parameter of an infobox.
+type InfoboxField struct {
+	Name  string          // the parameter name, e.g. "birth_place"
+	Value string          // the cleaned plain text value
+	Links []string        // the titles of the pages linked from the value
+	Nodes []wikitext.Node // the parsed wikitext of the value
+}
+
+// Get returns the field with the given name, matched case-insensitively.
+func (i *Infobox) Get(name string) (*InfoboxField, bool) {
+	return lo.Find(i.Fields, func(f *InfoboxField) bool { return strings.EqualFold(f.Name, name) })
+}
+
+// GetInfobox returns the first infobox of the page with the given title.
+// Templates nested in the values are rendered as their positional parameters separated by commas.
+func (c *Client) GetInfobox(ctx context.Context, title string) (*Infobox, error) {
+	wt, err := c.GetWikitextByTitle(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := wikitext.Parse(wt.Content)
+	t, ok := lo.Find(wikitext.FindAll[*wikitext.Template](doc.Nodes), func(t *wikitext.Template) bool {
+		return strings.HasPrefix(strings.ToLower(t.Name), "infobox")
+	})
+	if !ok {
+		return nil, fmt.Errorf("go-wikipedia: infobox not found")
+	}
+
+	infobox := &Infobox{Name: t.Name}
+	for _, p := range t.Params {
+		links := wikitext.FindAll[*wikitext.InternalLink](p.Value)
+		infobox.Fields = append(infobox.Fields, &InfoboxField{
+			Name:  p.Name,
+			Value: cleanInfoboxValue(p.Value),
+			Links: lo.Uniq(lo.Map(links, func(l *wikitext.InternalLink, _ int) string { return l.Title })),
+			Nodes: p.Value,
+		})
+	}
+
+	return infobox, nil
+}
+
+func cleanInfoboxValue(nodes []wikitext.Node) string {
+	text := wikitext.PlainText(expandTemplates(nodes))
+	text = breakRegexp.ReplaceAllString(text, ", ")
+	text = tagRegexp.ReplaceAllString(text, "")
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(text, " "))
+}
+
+// expandTemplates replaces the templates by their positional parameters separated by commas.
+func expandTemplates(nodes []wikitext.Node) []wikitext.Node {
+	var expanded []wikitext.Node
+	for _, n := range nodes {
+		t, ok := n.(*wikitext.Template)
+		if !ok {
+			expanded = append(expanded, n)
+			continue
+		}
+
+		var values [][]wikitext.Node
+		for _, p := range t.Params {
+			if v := expandTemplates(p.Value); p.Positional && len(wikitext.PlainText(v)) > 0 {
+				values = append(values, v)
+			}
+		}
+		for i, v := range values {
+			if i > 0 {
+				expanded = append(expanded, &wikitext.Text{Value: ", "})
+			}
+			expanded = append(expanded, v...)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,93 @@
+package wikipedia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetInfobox(t *testing.T) {
+	content, err := json.Marshal(`{{Short description|44th U.S. president}}
+{{Infobox officeholder
+| name        = Barack Obama
+| image       = President Barack Obama.jpg <!-- official portrait -->
+| birth_date  = {{birth date and age|1961|8|4}}
+| birth_place = [[Kapiolani Medical Center|Kapiolani Medical Center for Women and Children]]<br />
+[[Honolulu]], [[Hawaii]], U.S.
+| party       = [[Democratic Party (United States)|Democratic]]
+}}
+'''Barack Hussein Obama II''' is an American politician.`)
+	require.NoError(t, err)
+
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "prop", "revisions") || !checkQuery(r.Form, "titles", "Barack Obama") {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "revisions": [
+                    {
+                        "revid": 1165884406,
+                        "parentid": 1165765677,
+                        "slots": {"main": {"contentmodel": "wikitext", "*": %s}}
+                    }
+                ]
+            }
+        }
+    }
+}`, content)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetInfobox(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Equal(t, "Infobox officeholder", got.Name)
+
+	var fields [][]any
+	for _, f := range got.Fields {
+		fields = append(fields, []any{f.Name, f.Value, f.Links})
+	}
+	require.Equal(
+		t,
+		[][]any{
+			{"name", "Barack Obama", []string{}},
+			{"image", "President Barack Obama.jpg", []string{}},
+			{"birth_date", "1961, 8, 4", []string{}},
+			{
+				"birth_place",
+				"Kapiolani Medical Center for Women and Children, Honolulu, Hawaii, U.S.",
+				[]string{"Kapiolani Medical Center", "Honolulu", "Hawaii"},
+			},
+			{"party", "Democratic", []string{"Democratic Party (United States)"}},
+		},
+		fields,
+	)
+
+	party, ok := got.Get("Party")
+	require.True(t, ok)
+	require.Equal(t, "Democratic", party.Value)
+}
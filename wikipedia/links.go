@@ -0,0 +1,51 @@
+package wikipedia
+
+import "context"
+
+type linksRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	Titles        string   `url:"titles" json:"titles"`
+	PlNamespace   []int    `url:"plnamespace,omitempty" del:"|" json:"plnamespace"`
+	PlLimit       string   `url:"pllimit" json:"pllimit"`
+	PlContinue    string   `url:"plcontinue,omitempty" json:"plcontinue"`
+	Continue      string   `url:"continue,omitempty" json:"continue"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func (r *linksRequest) setContinue(rc responseContinue) bool {
+	r.PlContinue, r.Continue = rc.PlContinue, rc.Continue
+	return len(rc.PlContinue) > 0
+}
+
+// GetPageLinks returns the titles of all the pages linked from the page with the given title,
+// restricted to the given namespaces if any.
+func (c *Client) GetPageLinks(ctx context.Context, title string, namespaces ...int) ([]string, error) {
+	r := &linksRequest{
+		Action:        ActionQuery,
+		Props:         []string{"links"},
+		Titles:        title,
+		PlNamespace:   namespaces,
+		PlLimit:       "max",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+
+	var links []string
+	err := c.doContinue(ctx, r, func(response *apiResult) error {
+		page, err := response.Query.titlePage(title)
+		if err != nil {
+			return err
+		}
+		for _, l := range page.Link {
+			links = append(links, l.Title)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
@@ -0,0 +1,142 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageLinks(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "prop", "links") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "titles", "Barack Obama") {
+			http.Error(w, "invalid titles", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "plnamespace", "0|14") {
+			http.Error(w, "invalid plnamespace", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "pllimit", "max") {
+			http.Error(w, "invalid pllimit", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Form.Get("plcontinue") {
+		case "":
+			fmt.Fprint(w, `
+{
+    "continue": {"plcontinue": "534366|0|Chicago", "continue": "||"},
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "links": [
+                    {"ns": 0, "title": "Ann Dunham"},
+                    {"ns": 0, "title": "Barack Obama Sr."}
+                ]
+            }
+        }
+    }
+}`)
+		case "534366|0|Chicago":
+			if !checkQuery(r.Form, "continue", "||") {
+				http.Error(w, "invalid continue", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "links": [
+                    {"ns": 0, "title": "Chicago"},
+                    {"ns": 14, "title": "Category:Living people"}
+                ]
+            }
+        }
+    }
+}`)
+		default:
+			http.Error(w, "invalid plcontinue", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageLinks(context.TODO(), "Barack Obama", 0, 14)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Ann Dunham", "Barack Obama Sr.", "Chicago", "Category:Living people"}, got)
+}
+
+func TestClient_GetPageLinksErrors(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		switch r.Form.Get("titles") {
+		case "barack obama":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "normalized": [{"from": "barack obama", "to": "Barack obama"}],
+        "pages": {
+            "-1": {"ns": 0, "title": "Barack obama", "missing": ""}
+        }
+    }
+}`)
+		case "Special:Random":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "-1": {"ns": -1, "title": "Special:Random", "special": ""}
+        }
+    }
+}`)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	_, err = c.GetPageLinks(context.TODO(), "barack obama")
+	var missing *MissingPageError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, &MissingPageError{Title: "Barack obama"}, missing)
+	require.ErrorIs(t, err, ErrPageNotFound)
+
+	_, err = c.GetPageLinks(context.TODO(), "Special:Random")
+	var special *SpecialPageError
+	require.ErrorAs(t, err, &special)
+}
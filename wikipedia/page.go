@@ -3,9 +3,7 @@ package wikipedia
 import (
 	"context"
 	"fmt"
-	"strconv"
 
-	"github.com/anaskhan96/soup"
 	"github.com/samber/lo"
 )
 
@@ -14,7 +12,20 @@ type GetPageOption func(*GetPageOptions)
 
 // GetPageOptions are the options for the Wikipedia page request.
 type GetPageOptions struct {
-	Redirects bool
+	Redirects      bool
+	MaxRedirects   int
+	Links          bool
+	LinkNamespaces []int
+	Categories     bool
+	HiddenCategory bool
+	References     *ExternalLinksOptions
+	Images         bool
+	Coordinates    bool
+	Summary        *SummaryOptions
+	Sections       bool
+	HTML           *HTMLOptions
+	Extract        *ExtractOptions
+	Disambiguation DisambiguationMode
 }
 
 // WithGetPageRedirects sets the redirects option for the Wikipedia page request.
@@ -24,29 +35,119 @@ func WithGetPageRedirects(r bool) GetPageOption {
 	}
 }
 
+// WithGetPageMaxRedirects sets the max number of redirects followed when the redirects option is set.
+func WithGetPageMaxRedirects(n int) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.MaxRedirects = n
+	}
+}
+
+// WithGetPageLinks fills the page links, restricted to the given namespaces if any.
+func WithGetPageLinks(namespaces ...int) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Links = true
+		o.LinkNamespaces = namespaces
+	}
+}
+
+// WithGetPageCategories fills the page categories, including the hidden maintenance categories if hidden is true.
+func WithGetPageCategories(hidden bool) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Categories = true
+		o.HiddenCategory = hidden
+	}
+}
+
+// WithGetPageReferences fills the page references with its external links matching the given options.
+func WithGetPageReferences(opts *ExternalLinksOptions) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.References = lo.Ternary(opts != nil, opts, &ExternalLinksOptions{})
+	}
+}
+
+// WithGetPageImages fills the page images with the URLs of the files used on the page.
+func WithGetPageImages() GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Images = true
+	}
+}
+
+// WithGetPageCoordinates fills the page coordinate with the latitude and longitude of its primary coordinates.
+func WithGetPageCoordinates() GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Coordinates = true
+	}
+}
+
+// WithGetPageSummary fills the page summary with its plain text lead section.
+func WithGetPageSummary(opts *SummaryOptions) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Summary = lo.Ternary(opts != nil, opts, &SummaryOptions{})
+	}
+}
+
+// WithGetPageSections fills the page sections, and their offsets when the page content is requested.
+func WithGetPageSections() GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Sections = true
+	}
+}
+
+// WithGetPageHTML fills the page HTML with its rendered HTML.
+func WithGetPageHTML(opts *HTMLOptions) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.HTML = lo.Ternary(opts != nil, opts, &HTMLOptions{})
+	}
+}
+
+// WithGetPageExtract sets the format of the page content extract.
+func WithGetPageExtract(opts *ExtractOptions) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Extract = opts
+	}
+}
+
+// WithGetPageDisambiguation sets the handling of disambiguation pages, DisambiguationResolve by default.
+func WithGetPageDisambiguation(mode DisambiguationMode) GetPageOption {
+	return func(o *GetPageOptions) {
+		o.Disambiguation = mode
+	}
+}
+
 func defaultGetPageOptions() *GetPageOptions {
-	return &GetPageOptions{Redirects: false}
+	return &GetPageOptions{Redirects: false, MaxRedirects: defaultMaxRedirects}
+}
+
+func newGetPageOptions(opts ...GetPageOption) *GetPageOptions {
+	o := defaultGetPageOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 type pageRequest struct {
-	Action  Action   `url:"action" json:"action"`
-	PageIDs []int    `url:"pageids" del:"|" json:"pageids"`
-	Titles  []string `url:"titles" del:"|" json:"titles"`
-	Props   []string `url:"prop" del:"|" json:"prop"`
-	InProp  string   `url:"inprop" json:"inprop"`
-	PpProp  string   `url:"ppprop" json:"ppprop"`
-	Format  string   `url:"format"`
+	Action        Action   `url:"action" json:"action"`
+	PageIDs       []int    `url:"pageids,omitempty" del:"|" json:"pageids"`
+	Titles        []string `url:"titles,omitempty" del:"|" json:"titles"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	InProp        string   `url:"inprop" json:"inprop"`
+	PpProp        string   `url:"ppprop" json:"ppprop"`
+	Redirects     bool     `url:"redirects,omitempty" json:"redirects"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
 }
 
 // GetPage returns a wikipedia page from the wikipedia API endpoint by given page id.
 func (c *Client) GetPage(ctx context.Context, id int, opts ...GetPageOption) (*Page, error) {
 	r := &pageRequest{
-		Action:  ActionQuery,
-		PageIDs: []int{id},
-		Props:   []string{"info", "pageprops"},
-		InProp:  "url",
-		PpProp:  "disambiguation",
-		Format:  "json",
+		Action:        ActionQuery,
+		PageIDs:       []int{id},
+		Props:         []string{"info", "pageprops"},
+		InProp:        "url",
+		PpProp:        "disambiguation",
+		Format:        "json",
+		FormatVersion: 2,
 	}
 	return c.page(ctx, r, opts...)
 }
@@ -54,151 +155,218 @@ func (c *Client) GetPage(ctx context.Context, id int, opts ...GetPageOption) (*P
 // GetPageByTitle returns a wikipedia page from the wikipedia API endpoint by given page title.
 func (c *Client) GetPageByTitle(ctx context.Context, title string, opts ...GetPageOption) (*Page, error) {
 	r := &pageRequest{
-		Action: ActionQuery,
-		Titles: []string{title},
-		Props:  []string{"info", "pageprops"},
-		InProp: "url",
-		PpProp: "disambiguation",
-		Format: "json",
+		Action:        ActionQuery,
+		Titles:        []string{title},
+		Props:         []string{"info", "pageprops"},
+		InProp:        "url",
+		PpProp:        "disambiguation",
+		Format:        "json",
+		FormatVersion: 2,
 	}
 	return c.page(ctx, r, opts...)
 }
 
 func (c *Client) page(ctx context.Context, request *pageRequest, opts ...GetPageOption) (*Page, error) {
-	o := defaultGetPageOptions()
-	for _, opt := range opts {
-		opt(o)
-	}
+	o := newGetPageOptions(opts...)
+	request.Redirects = o.Redirects
 
 	response, err := c.do(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(response.Query.Pages) == 0 {
-		return nil, fmt.Errorf("go-wikipedia: page not found")
+	var (
+		id    int
+		title string
+	)
+	switch {
+	case len(request.Titles) > 0:
+		title = request.Titles[0]
+	case len(response.Query.Redirect) > 0:
+		title = redirectRoot(response.Query)
+	default:
+		id = request.PageIDs[0]
 	}
 
-	page, err := response.Query.oneOfPage()
+	steps, title, err := redirectChain(title, response.Query, o.MaxRedirects)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(page.Missing) > 0 {
-		return nil, fmt.Errorf("go-wikipedia: page not found")
+	page, err := response.Query.findPage(id, title)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(response.Query.Redirect) > 0 {
+	if page.Redirect {
 		if !o.Redirects {
-			return nil, fmt.Errorf("go-wikipedia: page is a redirect, set Redirects option to true to follow redirects")
+			return nil, fmt.Errorf("%w, set Redirects option to true to follow redirects", ErrRedirect)
 		}
-		return c.redirect(ctx, page.Title, response.Query)
+		return nil, fmt.Errorf("%w not resolved: %q", ErrRedirect, page.Title)
 	}
 
-	if _, ok := page.PageProps["disambiguation"]; ok {
-		return c.disambiguate(ctx, &page)
+	p, err := c.newPage(ctx, page, o)
+	if err != nil {
+		return nil, err
 	}
+	p.setRedirects(steps)
 
+	return p, nil
+}
+
+// newPage returns the page of the given API page, handling disambiguation and filling the
+// optional fields requested by the options.
+func (c *Client) newPage(ctx context.Context, page innerPage, o *GetPageOptions) (*Page, error) {
 	var rev revision
 	if len(page.Revisions) > 0 {
 		rev = page.Revisions[0]
 	}
 
-	return &Page{
+	p := &Page{
 		PageID:     page.PageID,
 		Title:      page.Title,
 		URL:        page.FullURL,
 		RevisionID: rev.RevID,
 		ParentID:   rev.ParentID,
-	}, nil
-}
+	}
+
+	if _, ok := page.PageProps["disambiguation"]; ok {
+		p.IsDisambiguation = true
+		if o.Disambiguation != DisambiguationFlag {
+			if err := c.disambiguate(ctx, p); err != nil {
+				return nil, err
+			}
+		}
+		if o.Disambiguation == DisambiguationFail {
+			return nil, &DisambiguationError{Title: p.Title, Options: p.DisambiguationOptions}
+		}
+	}
+
+	if err := c.fillPage(ctx, p, o); err != nil {
+		return nil, err
+	}
 
-type disambiguationRequest struct {
-	Action  Action   `url:"action" json:"action"`
-	Props   []string `url:"prop" del:"|" json:"prop"`
-	Titles  string   `url:"titles" json:"titles"`
-	RvProp  string   `url:"rvprop" json:"rvprop"`
-	RvLimit int      `url:"rvlimit" json:"rvlimit"`
-	Format  string   `url:"format"`
+	return p, nil
 }
 
-func (c *Client) disambiguate(ctx context.Context, page *innerPage) (*Page, error) {
-	r := &disambiguationRequest{
-		Action:  ActionQuery,
-		Titles:  page.Title,
-		Props:   []string{"revisions"},
-		RvProp:  "content",
-		RvLimit: 1,
-		Format:  "json",
+// fillPage fills the optional fields of the page requested by the options.
+func (c *Client) fillPage(ctx context.Context, p *Page, o *GetPageOptions) error {
+	fillers := []struct {
+		enabled bool
+		fill    func(context.Context, *Page, *GetPageOptions) error
+	}{
+		{o.Links, c.fillLinks},
+		{o.Categories, c.fillCategories},
+		{o.References != nil, c.fillReferences},
+		{o.Images, c.fillImages},
+		{o.Coordinates, c.fillCoordinates},
+		{o.Summary != nil, c.fillSummary},
+		{o.Sections, c.fillSections},
+		{o.HTML != nil, c.fillHTML},
+	}
+	for _, f := range fillers {
+		if !f.enabled {
+			continue
+		}
+		if err := f.fill(ctx, p, o); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	response, err := c.do(ctx, r)
+func (c *Client) fillLinks(ctx context.Context, p *Page, o *GetPageOptions) error {
+	links, err := c.GetPageLinks(ctx, p.Title, o.LinkNamespaces...)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	p.Link = links
+	return nil
+}
 
-	var html string
-	if v, ok := response.Query.Pages[strconv.Itoa(page.PageID)]; ok {
-		if len(v.Revisions) > 0 {
-			html = v.Revisions[0].Star
+func (c *Client) fillCategories(ctx context.Context, p *Page, o *GetPageOptions) error {
+	categories, err := c.GetPageCategories(ctx, p.Title)
+	if err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		if !cat.Hidden || o.HiddenCategory {
+			p.Category = append(p.Category, cat.Title)
 		}
 	}
-	if len(html) == 0 {
-		return nil, fmt.Errorf("go-wikipedia: disambiguation page not found")
+	return nil
+}
+
+func (c *Client) fillReferences(ctx context.Context, p *Page, o *GetPageOptions) error {
+	references, err := c.GetPageExternalLinks(ctx, p.Title, o.References)
+	if err != nil {
+		return err
 	}
+	p.Reference = references
+	return nil
+}
 
-	doc := soup.HTMLParse(html)
-	var d []string
-	for _, link := range doc.FindAll("li") {
-		for _, l := range link.FindAll("a") {
-			if ref, ok := l.Attrs()["title"]; ok {
-				if len(ref) >= 1 && !lo.Contains(d, ref) {
-					d = append(d, ref)
-				}
-			}
-		}
+func (c *Client) fillImages(ctx context.Context, p *Page, _ *GetPageOptions) error {
+	images, err := c.GetPageImages(ctx, p.Title, 0)
+	if err != nil {
+		return err
 	}
+	p.Images = lo.Map(images, func(i *Image, _ int) string { return i.URL })
+	p.CheckedImage = true
+	return nil
+}
 
-	var rev revision
-	if len(page.Revisions) > 0 {
-		rev = page.Revisions[0]
+func (c *Client) fillCoordinates(ctx context.Context, p *Page, _ *GetPageOptions) error {
+	coordinates, err := c.GetPageCoordinates(ctx, p.Title, false)
+	if err != nil {
+		return err
+	}
+	if primary, ok := lo.Find(coordinates, func(co *Coordinate) bool { return co.Primary }); ok {
+		p.Coordinate = []float64{primary.Lat, primary.Lon}
 	}
-	return &Page{
-		PageID:         page.PageID,
-		Title:          page.Title,
-		URL:            page.FullURL,
-		RevisionID:     rev.RevID,
-		ParentID:       rev.ParentID,
-		Disambiguation: d,
-	}, nil
+	return nil
 }
 
-func (c *Client) redirect(ctx context.Context, title string, rq responseQuery) (*Page, error) {
-	var (
-		t = title
-		r = rq.Redirect[0]
-	)
-	if len(rq.Normalize) > 0 {
-		n := rq.Normalize[0]
-		if n.From != title {
-			return nil, fmt.Errorf("go-wikipedia: unexpected normalize response")
-		}
-		t = n.To
+func (c *Client) fillSummary(ctx context.Context, p *Page, o *GetPageOptions) error {
+	summary, err := c.GetSummary(ctx, p.Title, o.Summary)
+	if err != nil {
+		return err
 	}
+	p.Summary = summary
+	return nil
+}
 
-	if r.From == t {
-		return nil, fmt.Errorf("go-wikipedia: unexpected redirect response")
+func (c *Client) fillSections(ctx context.Context, p *Page, _ *GetPageOptions) error {
+	tree, err := c.GetSections(ctx, p.Title)
+	if err != nil {
+		return err
 	}
+	p.sections = tree
+	p.Section = lo.Map(tree.Flatten(), func(s *Section, _ int) string { return s.Title })
+	return nil
+}
 
-	return c.GetPageByTitle(ctx, r.To, WithGetPageRedirects(true))
+func (c *Client) fillHTML(ctx context.Context, p *Page, o *GetPageOptions) error {
+	text, err := c.GetPageHTML(ctx, p.Title, o.HTML)
+	if err != nil {
+		return err
+	}
+	p.HTML = text
+	return nil
 }
 
 type pageContentRequest struct {
-	Action Action   `url:"action" json:"action"`
-	Props  []string `url:"prop" del:"|" json:"prop"`
-	RvProp string   `url:"rvprop" json:"rvprop"`
-	Titles string   `url:"titles" json:"titles"`
-	Format string   `url:"format"`
+	Action          Action               `url:"action" json:"action"`
+	Props           []string             `url:"prop" del:"|" json:"prop"`
+	RvProp          string               `url:"rvprop" json:"rvprop"`
+	Titles          string               `url:"titles" json:"titles"`
+	ExIntro         bool                 `url:"exintro,omitempty" json:"exintro"`
+	ExPlainText     bool                 `url:"explaintext,omitempty" json:"explaintext"`
+	ExSectionFormat ExtractSectionFormat `url:"exsectionformat,omitempty" json:"exsectionformat"`
+	ExSentences     int                  `url:"exsentences,omitempty" json:"exsentences"`
+	ExChars         int                  `url:"exchars,omitempty" json:"exchars"`
+	Format          string               `url:"format"`
+	FormatVersion   int                  `url:"formatversion,omitempty"`
 }
 
 // GetPageContent returns a wikipedia page content from the wikipedia API endpoint by given page id.
@@ -208,7 +376,7 @@ func (c *Client) GetPageContent(ctx context.Context, id int, opts ...GetPageOpti
 		return nil, err
 	}
 
-	return c.pageContent(ctx, p)
+	return c.pageContent(ctx, p, newGetPageOptions(opts...).Extract)
 }
 
 // GetPageContentByTitle returns a wikipedia page content from the wikipedia API endpoint by given page title.
@@ -218,33 +386,61 @@ func (c *Client) GetPageContentByTitle(ctx context.Context, title string, opts .
 		return nil, err
 	}
 
-	return c.pageContent(ctx, p)
+	return c.pageContent(ctx, p, newGetPageOptions(opts...).Extract)
 }
 
-func (c *Client) pageContent(ctx context.Context, p *Page) (*PageContent, error) {
+func (c *Client) pageContent(ctx context.Context, p *Page, eo *ExtractOptions) (*PageContent, error) {
+	if eo == nil {
+		eo = &ExtractOptions{}
+	}
+
 	r := &pageContentRequest{
-		Action: ActionQuery,
-		Props:  []string{"extracts", "revisions"},
-		RvProp: "ids",
-		Titles: p.Title,
-		Format: "json",
+		Action:          ActionQuery,
+		Props:           []string{"extracts", "revisions"},
+		RvProp:          "ids",
+		Titles:          p.Title,
+		ExIntro:         eo.IntroOnly,
+		ExPlainText:     eo.PlainText,
+		ExSectionFormat: eo.SectionFormat,
+		ExSentences:     eo.Sentences,
+		ExChars:         eo.Chars,
+		Format:          "json",
+		FormatVersion:   2,
 	}
 	response, err := c.do(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 
-	pv, ok := response.Query.Pages[strconv.Itoa(p.PageID)]
-	if !ok {
-		return nil, fmt.Errorf("go-wikipedia: page not found: %d", p.PageID)
+	pv, err := response.Query.findPage(p.PageID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var rev revision
+	if len(pv.Revisions) > 0 {
+		rev = pv.Revisions[0]
 	}
 
-	return &PageContent{
+	pc := &PageContent{
 		Page:       p,
 		Content:    pv.Extract,
-		RevisionID: lo.Ternary(len(pv.Revisions) > 0, pv.Revisions[0].RevID, 0),
-		ParentID:   lo.Ternary(len(pv.Revisions) > 0, pv.Revisions[0].ParentID, 0),
-	}, nil
+		RevisionID: rev.RevID,
+		ParentID:   rev.ParentID,
+	}
+
+	if p.sections != nil {
+		p.sections.Locate(pc.Content)
+		p.SectionOffset = make(map[string][]int, len(p.Section))
+		for _, s := range p.sections.Flatten() {
+			if _, ok := p.SectionOffset[s.Title]; !ok && s.Start >= 0 {
+				p.SectionOffset[s.Title] = []int{s.Start, s.End}
+			}
+		}
+		pc.Sections = p.sections
+	}
+
+	return pc, nil
 }
 
 // PageContent represents a wikipedia page content.
@@ -253,6 +449,7 @@ type PageContent struct {
 	Content    string
 	RevisionID int
 	ParentID   int
+	Sections   *SectionTree // the sections located in the content, if requested
 }
 
 // Page represents a wikipedia page info.
@@ -273,4 +470,11 @@ type Page struct {
 	Section        []string         `json:"sections"`
 	SectionOffset  map[string][]int `json:"sectionoffset"`
 	Disambiguation []string         `json:"disambiguation"`
+	Redirects      []*RedirectStep  `json:"redirects"` // the steps resolving the requested title, if any
+	Fragment       string           `json:"fragment"`  // the section anchor of the redirect to the page, if any
+
+	IsDisambiguation      bool                    `json:"isdisambiguation"`
+	DisambiguationOptions []*DisambiguationOption `json:"disambiguationoptions"`
+
+	sections *SectionTree
 }
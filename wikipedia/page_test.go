@@ -2,11 +2,13 @@ package wikipedia
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	"github.com/majdus/go-wikipedia/internal/testhelper"
@@ -276,3 +278,177 @@ func TestClient_GetPageContent(t *testing.T) {
 		got,
 	)
 }
+
+func TestClient_GetPageContentWithExtract(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if checkQuery(r.Form, "prop", "info|pageprops") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        }
+    }
+}`)
+			return
+		}
+		for k, v := range map[string]string{
+			"prop":            "extracts|revisions",
+			"explaintext":     "true",
+			"exsectionformat": "plain",
+			"exchars":         "500",
+			"exintro":         "true",
+		} {
+			if !checkQuery(r.Form, k, v) {
+				http.Error(w, "invalid "+k, http.StatusBadRequest)
+				return
+			}
+		}
+		if _, ok := r.Form["exsentences"]; ok {
+			http.Error(w, "unexpected exsentences", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "extract": "Barack Hussein Obama II is an American politician.",
+                "revisions": [{"revid": 1165884406, "parentid": 1165765677}]
+            }
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageContentByTitle(context.TODO(), "Barack Obama", WithGetPageExtract(&ExtractOptions{
+		PlainText:     true,
+		SectionFormat: ExtractSectionFormatPlain,
+		Chars:         500,
+		IntroOnly:     true,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "Barack Hussein Obama II is an American politician.", got.Content)
+	require.Equal(t, 1165884406, got.RevisionID)
+}
+
+func TestClient_GetPageByTitleErrors(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "formatversion", "2") {
+			http.Error(w, "invalid formatversion", http.StatusBadRequest)
+			return
+		}
+		switch r.Form.Get("titles") {
+		case "Barack Obama":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {
+        "pages": [
+            {"ns": 0, "title": "Obama", "missing": true},
+            {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        ]
+    }
+}`)
+		case "Not a page":
+			fmt.Fprint(w, `{"batchcomplete": true, "query": {"pages": [{"ns": 0, "title": "Not a page", "missing": true}]}}`)
+		case "Foo<bar>":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {
+        "pages": [
+            {
+                "title": "Foo<bar>",
+                "invalidreason": "The requested page title contains invalid characters: \"<\".",
+                "invalid": true
+            }
+        ]
+    }
+}`)
+		case "Special:Random":
+			fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {"pages": [{"ns": -1, "title": "Special:Random", "special": true}]}
+}`)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPageByTitle(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&Page{PageID: 534366, Title: "Barack Obama", URL: "https://en.wikipedia.org/wiki/Barack_Obama"},
+		got,
+	)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Not a page")
+	var missing *MissingPageError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, &MissingPageError{Title: "Not a page"}, missing)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Foo<bar>")
+	var invalid *InvalidTitleError
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(
+		t,
+		&InvalidTitleError{Title: "Foo<bar>", Reason: `The requested page title contains invalid characters: "<".`},
+		invalid,
+	)
+
+	_, err = c.GetPageByTitle(context.TODO(), "Special:Random")
+	var special *SpecialPageError
+	require.ErrorAs(t, err, &special)
+	require.EqualError(t, err, `go-wikipedia: special page: "Special:Random"`)
+}
+
+func TestPageList_UnmarshalJSON(t *testing.T) {
+	var got pageList
+	require.NoError(t, json.Unmarshal([]byte(`{
+    "10": {"pageid": 10, "title": "Ten"},
+    "-1": {"title": "Missing", "missing": ""},
+    "9": {"pageid": 9, "title": "Nine"}
+}`), &got))
+	require.Equal(t, []string{"Missing", "Nine", "Ten"}, lo.Map(got, func(p innerPage, _ int) string { return p.Title }))
+
+	require.NoError(t, json.Unmarshal([]byte(`[{"pageid": 10, "title": "Ten"}, {"pageid": 9, "title": "Nine"}]`), &got))
+	require.Equal(t, []string{"Ten", "Nine"}, lo.Map(got, func(p innerPage, _ int) string { return p.Title }))
+}
@@ -0,0 +1,118 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// PageResult is the result of the lookup of a single page of a batch request.
+type PageResult struct {
+	ID    int    // the requested page id, zero for a lookup by title
+	Title string // the requested page title, empty for a lookup by id
+	Page  *Page  // the page found, nil if the lookup failed
+	Err   error  // the error of the lookup of this page, e.g. a missing page
+}
+
+func newPagesRequest() *pageRequest {
+	return &pageRequest{
+		Action:        ActionQuery,
+		Props:         []string{"info", "pageprops"},
+		InProp:        "url",
+		PpProp:        "disambiguation",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+}
+
+// GetPages returns the wikipedia pages by given page ids, requesting up to 50 pages at once.
+// The results are in the order of the ids, each reporting the error of its own lookup; the
+// returned error is only set when a whole request fails.
+func (c *Client) GetPages(ctx context.Context, ids []int, opts ...GetPageOption) ([]*PageResult, error) {
+	o := newGetPageOptions(opts...)
+
+	results := lo.Map(ids, func(id int, _ int) *PageResult { return &PageResult{ID: id} })
+	var redirects []*PageResult
+	for _, chunk := range lo.Chunk(results, maxTitles) {
+		r := newPagesRequest()
+		r.PageIDs = lo.Map(chunk, func(res *PageResult, _ int) int { return res.ID })
+
+		response, err := c.do(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range chunk {
+			page, err := response.Query.findPage(res.ID, "")
+			switch {
+			case err != nil:
+				res.Err = err
+			case bool(page.Redirect) && o.Redirects:
+				res.Title = page.Title
+				redirects = append(redirects, res)
+			case bool(page.Redirect):
+				res.Err = fmt.Errorf("%w: %d, set Redirects option to true to follow redirects", ErrRedirect, res.ID)
+			default:
+				res.Page, res.Err = c.newPage(ctx, page, o)
+			}
+		}
+	}
+
+	if len(redirects) > 0 {
+		titles := lo.Map(redirects, func(res *PageResult, _ int) string { return res.Title })
+		resolved, err := c.GetPagesByTitle(ctx, titles, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for i, res := range redirects {
+			res.Title = ""
+			res.Page, res.Err = resolved[i].Page, resolved[i].Err
+		}
+	}
+
+	return results, nil
+}
+
+// GetPagesByTitle returns the wikipedia pages by given page titles, requesting up to 50 pages at once.
+// The results are in the order of the titles, each reporting the error of its own lookup; the
+// returned error is only set when a whole request fails. The titles are matched to the returned
+// pages through the normalizations and, if the Redirects option is set, the redirects of the API.
+func (c *Client) GetPagesByTitle(ctx context.Context, titles []string, opts ...GetPageOption) ([]*PageResult, error) {
+	o := newGetPageOptions(opts...)
+
+	results := lo.Map(titles, func(title string, _ int) *PageResult { return &PageResult{Title: title} })
+	for _, chunk := range lo.Chunk(results, maxTitles) {
+		r := newPagesRequest()
+		r.Titles = lo.Map(chunk, func(res *PageResult, _ int) string { return res.Title })
+		r.Redirects = o.Redirects
+
+		response, err := c.do(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range chunk {
+			steps, title, err := redirectChain(res.Title, response.Query, o.MaxRedirects)
+			if err != nil {
+				res.Err = err
+				continue
+			}
+
+			page, err := response.Query.findPage(0, title)
+			switch {
+			case err != nil:
+				res.Err = err
+			case bool(page.Redirect):
+				res.Err = fmt.Errorf("%w: %q, set Redirects option to true to follow redirects", ErrRedirect, res.Title)
+			default:
+				res.Page, res.Err = c.newPage(ctx, page, o)
+				if res.Page != nil {
+					res.Page.setRedirects(steps)
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
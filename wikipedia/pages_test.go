@@ -0,0 +1,153 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPages(t *testing.T) {
+	var requests [][]string
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "prop", "info|pageprops") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		ids := strings.Split(r.Form.Get("pageids"), "|")
+		requests = append(requests, ids)
+
+		pages := lo.Map(ids, func(id string, _ int) string {
+			if id == "42" {
+				return `"42": {"pageid": 42, "missing": ""}`
+			}
+			return fmt.Sprintf(`"%s": {"pageid": %s, "ns": 0, "title": "Page %s"}`, id, id, id)
+		})
+		fmt.Fprintf(w, `{"batchcomplete": "", "query": {"pages": {%s}}}`, strings.Join(pages, ","))
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	ids := lo.RangeFrom(1, 60)
+	got, err := c.GetPages(context.TODO(), ids)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	require.Len(t, requests[0], 50)
+	require.Len(t, requests[1], 10)
+	require.Len(t, got, 60)
+	for i, res := range got {
+		require.Equal(t, ids[i], res.ID)
+		if res.ID == 42 {
+			require.EqualError(t, res.Err, "go-wikipedia: page not found: 42")
+			require.Nil(t, res.Page)
+			continue
+		}
+		require.NoError(t, res.Err)
+		require.Equal(t, &Page{PageID: res.ID, Title: "Page " + strconv.Itoa(res.ID)}, res.Page)
+	}
+}
+
+func TestClient_GetPagesByTitle(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "titles", "obama|Barack Obama|Not a page|Foo<bar>|Mercury") {
+			http.Error(w, "invalid titles", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "redirects", "true") {
+			http.Error(w, "invalid redirects", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "normalized": [
+            {"from": "obama", "to": "Obama"}
+        ],
+        "redirects": [
+            {"from": "Obama", "to": "Barack Obama"}
+        ],
+        "pages": {
+            "-1": {
+                "ns": 0,
+                "title": "Not a page",
+                "missing": ""
+            },
+            "-2": {
+                "title": "Foo<bar>",
+                "invalidreason": "The requested page title contains invalid characters: \"<\".",
+                "invalid": ""
+            },
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            },
+            "19694": {
+                "pageid": 19694,
+                "ns": 0,
+                "title": "Mercury",
+                "fullurl": "https://en.wikipedia.org/wiki/Mercury",
+                "pageprops": {"disambiguation": ""}
+            }
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetPagesByTitle(
+		context.TODO(),
+		[]string{"obama", "Barack Obama", "Not a page", "Foo<bar>", "Mercury"},
+		WithGetPageRedirects(true),
+		WithGetPageDisambiguation(DisambiguationFlag),
+	)
+	require.NoError(t, err)
+	require.Len(t, got, 5)
+
+	obama := &Page{PageID: 534366, Title: "Barack Obama", URL: "https://en.wikipedia.org/wiki/Barack_Obama"}
+	require.Equal(t, &PageResult{Title: "Barack Obama", Page: obama}, got[1])
+	obama.Redirects = []*RedirectStep{
+		{From: "obama", To: "Obama", Normalized: true},
+		{From: "Obama", To: "Barack Obama"},
+	}
+	require.Equal(t, &PageResult{Title: "obama", Page: obama}, got[0])
+	require.EqualError(t, got[2].Err, `go-wikipedia: page not found: "Not a page"`)
+	require.EqualError(
+		t,
+		got[3].Err,
+		`go-wikipedia: invalid title "Foo<bar>": The requested page title contains invalid characters: "<".`,
+	)
+	require.Equal(
+		t,
+		&Page{PageID: 19694, Title: "Mercury", URL: "https://en.wikipedia.org/wiki/Mercury", IsDisambiguation: true},
+		got[4].Page,
+	)
+}
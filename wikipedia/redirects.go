@@ -0,0 +1,67 @@
+package wikipedia
+
+import "github.com/samber/lo"
+
+const defaultMaxRedirects = 10 // the default max number of redirects followed for a title
+
+// RedirectStep is a step of the resolution of a requested title to the title of the returned page.
+type RedirectStep struct {
+	From       string // the title before the step
+	To         string // the title after the step
+	Fragment   string // the section anchor the redirect points at, e.g. "Early life" for "Foo#Early life"
+	Normalized bool   // whether the step is a normalization of the title rather than a redirect
+}
+
+// redirectChain returns the steps resolving the given title through the normalizations and the
+// redirects of the API response, and the resolved title. It fails on redirect loops and on chains
+// longer than maxHops redirects with a *RedirectError.
+func redirectChain(title string, rq responseQuery, maxHops int) ([]*RedirectStep, string, error) {
+	var steps []*RedirectStep
+	if n, ok := lo.Find(rq.Normalize, func(n normalize) bool { return n.From == title }); ok {
+		steps = append(steps, &RedirectStep{From: n.From, To: n.To, Normalized: true})
+		title = n.To
+	}
+
+	seen := map[string]bool{title: true}
+	path := []string{title}
+	for hops := 0; ; hops++ {
+		r, ok := lo.Find(rq.Redirect, func(r normalize) bool { return r.From == title })
+		if !ok {
+			return steps, title, nil
+		}
+		if hops == maxHops {
+			return nil, "", &RedirectError{Path: path, MaxRedirects: maxHops}
+		}
+
+		steps = append(steps, &RedirectStep{From: r.From, To: r.To, Fragment: r.ToFragment})
+		title = r.To
+		path = append(path, title)
+		if seen[title] {
+			return nil, "", &RedirectError{Path: path, Loop: true, MaxRedirects: maxHops}
+		}
+		seen[title] = true
+	}
+}
+
+// redirectRoot returns the title starting the redirects of the API response, i.e. the title of
+// the page requested by id, or an empty string if there is no redirect.
+func redirectRoot(rq responseQuery) string {
+	for _, r := range rq.Redirect {
+		if !lo.ContainsBy(rq.Redirect, func(o normalize) bool { return o.To == r.From }) {
+			return r.From
+		}
+	}
+	if len(rq.Redirect) > 0 {
+		// every title is the target of another one, the redirects are looping
+		return rq.Redirect[0].From
+	}
+	return ""
+}
+
+// setRedirects sets the redirect steps resolving the requested title of the page.
+func (p *Page) setRedirects(steps []*RedirectStep) {
+	p.Redirects = steps
+	if r, ok := lo.Last(lo.Filter(steps, func(s *RedirectStep, _ int) bool { return !s.Normalized })); ok {
+		p.Fragment = r.Fragment
+	}
+}
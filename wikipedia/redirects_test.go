@@ -0,0 +1,115 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetPageByTitleRedirects(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "titles", "obama") {
+			http.Error(w, "invalid titles", http.StatusBadRequest)
+			return
+		}
+		if !r.Form.Has("redirects") {
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "normalized": [{"from": "obama", "to": "Obama"}],
+        "pages": {
+            "7404": {"pageid": 7404, "ns": 0, "title": "Obama", "redirect": ""}
+        }
+    }
+}`)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "normalized": [{"from": "obama", "to": "Obama"}],
+        "redirects": [
+            {"from": "Barack", "to": "Barack Obama", "tofragment": "Early life"},
+            {"from": "Obama", "to": "Barack"}
+        ],
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "fullurl": "https://en.wikipedia.org/wiki/Barack_Obama"
+            }
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	_, err = c.GetPageByTitle(context.TODO(), "obama")
+	require.EqualError(t, err, "go-wikipedia: page is a redirect, set Redirects option to true to follow redirects")
+
+	got, err := c.GetPageByTitle(context.TODO(), "obama", WithGetPageRedirects(true))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&Page{
+			PageID: 534366,
+			Title:  "Barack Obama",
+			URL:    "https://en.wikipedia.org/wiki/Barack_Obama",
+			Redirects: []*RedirectStep{
+				{From: "obama", To: "Obama", Normalized: true},
+				{From: "Obama", To: "Barack"},
+				{From: "Barack", To: "Barack Obama", Fragment: "Early life"},
+			},
+			Fragment: "Early life",
+		},
+		got,
+	)
+
+	_, err = c.GetPageByTitle(context.TODO(), "obama", WithGetPageRedirects(true), WithGetPageMaxRedirects(1))
+	require.EqualError(t, err, `go-wikipedia: too many redirects from "Obama", max 1`)
+}
+
+func TestRedirectChain(t *testing.T) {
+	rq := responseQuery{
+		Redirect: []normalize{
+			{From: "A", To: "B"},
+			{From: "B", To: "C"},
+			{From: "C", To: "A"},
+			{From: "D", To: "E"},
+		},
+	}
+
+	steps, title, err := redirectChain("D", rq, defaultMaxRedirects)
+	require.NoError(t, err)
+	require.Equal(t, "E", title)
+	require.Equal(t, []*RedirectStep{{From: "D", To: "E"}}, steps)
+
+	steps, title, err = redirectChain("E", rq, defaultMaxRedirects)
+	require.NoError(t, err)
+	require.Equal(t, "E", title)
+	require.Empty(t, steps)
+
+	_, _, err = redirectChain("A", rq, defaultMaxRedirects)
+	require.EqualError(t, err, "go-wikipedia: redirect loop: A -> B -> C -> A")
+
+	require.Equal(t, "D", redirectRoot(responseQuery{Redirect: rq.Redirect[3:]}))
+	require.Empty(t, redirectRoot(responseQuery{}))
+}
@@ -1,31 +1,126 @@
 package wikipedia
 
-import (
-	"context"
-	"errors"
+import "context"
+
+// SearchWhat is the kind of search to perform.
+type SearchWhat string
+
+const (
+	SearchWhatTitle     SearchWhat = "title"     // search page titles
+	SearchWhatText      SearchWhat = "text"      // search page text
+	SearchWhatNearMatch SearchWhat = "nearmatch" // search for a near match in the title
+)
+
+// SearchSort is the sort order of the search results.
+type SearchSort string
+
+const (
+	SearchSortCreateTimestampAsc  SearchSort = "create_timestamp_asc"  // oldest created first
+	SearchSortCreateTimestampDesc SearchSort = "create_timestamp_desc" // newest created first
+	SearchSortIncomingLinksAsc    SearchSort = "incoming_links_asc"    // least linked first
+	SearchSortIncomingLinksDesc   SearchSort = "incoming_links_desc"   // most linked first
+	SearchSortJustMatch           SearchSort = "just_match"            // matching pages, no scoring
+	SearchSortLastEditAsc         SearchSort = "last_edit_asc"         // least recently edited first
+	SearchSortLastEditDesc        SearchSort = "last_edit_desc"        // most recently edited first
+	SearchSortNone                SearchSort = "none"                  // no sorting
+	SearchSortRandom              SearchSort = "random"                // random order
+	SearchSortRelevance           SearchSort = "relevance"             // most relevant first, the API default
+	SearchSortUserRandom          SearchSort = "user_random"           // random order stable for the user
+)
+
+// SearchProp is a property returned for each search result.
+type SearchProp string
+
+const (
+	SearchPropSize            SearchProp = "size"            // size of the page in bytes
+	SearchPropWordCount       SearchProp = "wordcount"       // word count of the page
+	SearchPropTimestamp       SearchProp = "timestamp"       // timestamp of the last edit
+	SearchPropSnippet         SearchProp = "snippet"         // snippet of the matching text
+	SearchPropTitleSnippet    SearchProp = "titlesnippet"    // snippet of the matching title
+	SearchPropRedirectTitle   SearchProp = "redirecttitle"   // title of the matching redirect
+	SearchPropRedirectSnippet SearchProp = "redirectsnippet" // snippet of the matching redirect title
+	SearchPropSectionTitle    SearchProp = "sectiontitle"    // title of the matching section
+	SearchPropSectionSnippet  SearchProp = "sectionsnippet"  // snippet of the matching section title
+	SearchPropIsFileMatch     SearchProp = "isfilematch"     // whether the match is in the file content
+	SearchPropCategorySnippet SearchProp = "categorysnippet" // snippet of the matching category
 )
 
 // SearchOptions are the options for the Wikipedia search request.
 type SearchOptions struct {
-	SrLimit int // the max number of results returned, default 10
-	Limit   int // the max number of results returned, default 10
+	SrLimit          int          // the max number of results returned, default 10
+	SrOffset         int          // the offset of the first result returned, default 0
+	SrNamespace      []int        // the namespaces to search in, default the main namespace
+	SrWhat           SearchWhat   // the kind of search to perform, default the API default
+	SrSort           SearchSort   // the sort order of the results, default relevance
+	SrQiProfile      string       // the query independent ranking profile, default the API default
+	SrInterwiki      bool         // include the results from the sister projects, default false
+	SrEnableRewrites bool         // allow the API to rewrite the query, default false
+	SrProp           []SearchProp // the properties returned for each result, default the API default
 }
 
 func defaultSearchOptions() *SearchOptions {
 	return &SearchOptions{
 		SrLimit: defaultLimit,
-		Limit:   defaultLimit,
 	}
 }
 
 type SearchRequest struct {
-	Action   Action `url:"action"`
-	List     string `url:"list"`
-	SrProp   string `url:"srprop"`
-	SrLimit  int    `url:"srlimit"`
-	Limit    int    `url:"limit"`
-	SrSearch string `url:"srsearch"`
-	Format   string `url:"format"`
+	Action           Action       `url:"action"`
+	List             string       `url:"list"`
+	SrProp           []SearchProp `url:"srprop,omitempty" del:"|"`
+	SrLimit          int          `url:"srlimit"`
+	SrOffset         int          `url:"sroffset,omitempty"`
+	SrNamespace      []int        `url:"srnamespace,omitempty" del:"|"`
+	SrWhat           SearchWhat   `url:"srwhat,omitempty"`
+	SrSort           SearchSort   `url:"srsort,omitempty"`
+	SrQiProfile      string       `url:"srqiprofile,omitempty"`
+	SrInterwiki      bool         `url:"srinterwiki,omitempty"`
+	SrEnableRewrites bool         `url:"srenablerewrites,omitempty"`
+	SrInfo           string       `url:"srinfo"`
+	SrSearch         string       `url:"srsearch"`
+	Continue         string       `url:"continue,omitempty"`
+	Format           string       `url:"format"`
+}
+
+func newSearchRequest(query string, searchOptions *SearchOptions) *SearchRequest {
+	return &SearchRequest{
+		Action:           ActionQuery,
+		List:             "search",
+		SrProp:           searchOptions.SrProp,
+		SrLimit:          searchOptions.SrLimit,
+		SrOffset:         searchOptions.SrOffset,
+		SrNamespace:      searchOptions.SrNamespace,
+		SrWhat:           searchOptions.SrWhat,
+		SrSort:           searchOptions.SrSort,
+		SrQiProfile:      searchOptions.SrQiProfile,
+		SrInterwiki:      searchOptions.SrInterwiki,
+		SrEnableRewrites: searchOptions.SrEnableRewrites,
+		SrInfo:           "totalhits|suggestion|rewrittenquery",
+		SrSearch:         query,
+		Format:           "json",
+	}
+}
+
+// SearchResult is the result of a Wikipedia search request.
+type SearchResult struct {
+	Hits           []*SearchResponse // the results of the current page
+	TotalHits      int               // the total number of results matching the query
+	Suggestion     string            // the "did you mean" suggestion, if any
+	RewrittenQuery string            // the query actually run when the API rewrote it, if any
+
+	// Interwiki are the results from the sister projects by interwiki prefix, e.g. "wikt",
+	// if the SrInterwiki option is set.
+	Interwiki map[string][]*SearchResponse
+}
+
+func newSearchResult(resp *apiResult) *SearchResult {
+	return &SearchResult{
+		Hits:           resp.Query.Search,
+		TotalHits:      resp.Query.SearchInfo.TotalHits,
+		Suggestion:     resp.Query.SearchInfo.Suggestion,
+		RewrittenQuery: resp.Query.SearchInfo.RewrittenQuery,
+		Interwiki:      resp.Query.InterwikiSearch,
+	}
 }
 
 // Search searches the Wikipedia for the given query.
@@ -34,26 +129,119 @@ func (c *Client) Search(
 	query string,
 	searchOptions *SearchOptions,
 ) ([]*SearchResponse, error) {
+	res, err := c.SearchWithInfo(ctx, query, searchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Hits, nil
+}
+
+// SearchWithInfo searches the Wikipedia for the given query and returns the results
+// along with the total number of hits, the spelling suggestion and the rewritten query.
+func (c *Client) SearchWithInfo(
+	ctx context.Context,
+	query string,
+	searchOptions *SearchOptions,
+) (*SearchResult, error) {
 	if len(query) == 0 {
-		return nil, errors.New("go-wikipedia: query is empty")
+		return nil, ErrEmptyQuery
 	}
 
 	if searchOptions == nil {
 		searchOptions = defaultSearchOptions()
 	}
 
-	req := &SearchRequest{
-		Action:   ActionQuery,
-		List:     "search",
-		SrLimit:  searchOptions.SrLimit,
-		Limit:    searchOptions.Limit,
-		SrSearch: query,
-		Format:   "json",
-	}
-	resp, err := c.do(ctx, req)
+	resp, err := c.do(ctx, newSearchRequest(query, searchOptions))
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.Query.Search, nil
+	return newSearchResult(resp), nil
+}
+
+// SearchIterator iterates over the result pages of a Wikipedia search by following
+// the continue block returned by the API.
+type SearchIterator struct {
+	c       *Client
+	req     *SearchRequest
+	max     int
+	fetched int
+	done    bool
+	page    *SearchResult
+	err     error
+}
+
+// SearchIter returns an iterator over all the results of the given query.
+// The iteration stops when the API reports no more results or when max results
+// have been returned. A max lower or equal to zero means no limit.
+func (c *Client) SearchIter(query string, max int, searchOptions *SearchOptions) *SearchIterator {
+	if searchOptions == nil {
+		searchOptions = defaultSearchOptions()
+	}
+
+	it := &SearchIterator{
+		c:   c,
+		req: newSearchRequest(query, searchOptions),
+		max: max,
+	}
+	if len(query) == 0 {
+		it.err = ErrEmptyQuery
+		it.done = true
+	}
+
+	return it
+}
+
+// Next fetches the next page of results. It returns false when the iteration is
+// exhausted or an error occurred, see Err.
+func (it *SearchIterator) Next(ctx context.Context) bool {
+	it.page = nil
+	if it.done {
+		return false
+	}
+
+	if it.max > 0 && it.max-it.fetched < it.req.SrLimit {
+		it.req.SrLimit = it.max - it.fetched
+	}
+
+	resp, err := it.c.do(ctx, it.req)
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	it.page = newSearchResult(resp)
+	if it.max > 0 && it.fetched+len(it.page.Hits) > it.max {
+		it.page.Hits = it.page.Hits[:it.max-it.fetched]
+	}
+	it.fetched += len(it.page.Hits)
+
+	if len(it.page.Hits) == 0 || len(resp.Continue.Continue) == 0 || (it.max > 0 && it.fetched >= it.max) {
+		it.done = true
+	} else {
+		it.req.SrOffset = resp.Continue.Sroffset
+		it.req.Continue = resp.Continue.Continue
+	}
+
+	return len(it.page.Hits) > 0
+}
+
+// Page returns the results fetched by the last call to Next.
+func (it *SearchIterator) Page() []*SearchResponse {
+	if it.page == nil {
+		return nil
+	}
+	return it.page.Hits
+}
+
+// Result returns the results fetched by the last call to Next along with the search info.
+func (it *SearchIterator) Result() *SearchResult {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	"github.com/majdus/go-wikipedia/internal/testhelper"
@@ -102,7 +103,7 @@ func TestClient_Search(t *testing.T) {
 	require.NoError(t, err)
 
 	c.url = ts.URL() + "/w/api.php"
-	got, err := c.Search(context.TODO(), "Barack Obama", func(searchOptions *SearchOptions) { searchOptions.Limit = 10 })
+	got, err := c.Search(context.TODO(), "Barack Obama", &SearchOptions{SrLimit: 10})
 	require.NoError(t, err)
 	require.Equal(
 		t,
@@ -118,6 +119,248 @@ func TestClient_Search(t *testing.T) {
 			"Early life and career of Barack Obama",
 			"Cabinet of Barack Obama",
 		},
+		lo.Map(got, func(r *SearchResponse, _ int) string { return r.Title }),
+	)
+}
+
+func TestClient_SearchIter(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+
+		switch r.Form.Get("sroffset") {
+		case "":
+			if !checkQuery(r.Form, "srlimit", "2") {
+				http.Error(w, "invalid srlimit", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "continue": {"sroffset": 2, "continue": "-||"},
+    "query": {
+        "search": [
+            {"ns": 0, "title": "Barack Obama", "pageid": 534366},
+            {"ns": 0, "title": "Barack Obama Sr.", "pageid": 16136849}
+        ]
+    }
+}`)
+		case "2":
+			if !checkQuery(r.Form, "continue", "-||") {
+				http.Error(w, "invalid continue", http.StatusBadRequest)
+				return
+			}
+			if !checkQuery(r.Form, "srlimit", "1") {
+				http.Error(w, "invalid srlimit", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "continue": {"sroffset": 3, "continue": "-||"},
+    "query": {
+        "search": [
+            {"ns": 0, "title": "Family of Barack Obama", "pageid": 17775180}
+        ]
+    }
+}`)
+		default:
+			http.Error(w, "unexpected sroffset", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	it := c.SearchIter("Barack Obama", 3, &SearchOptions{SrLimit: 2})
+
+	var got []string
+	for it.Next(context.TODO()) {
+		for _, r := range it.Page() {
+			got = append(got, r.Title)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"Barack Obama", "Barack Obama Sr.", "Family of Barack Obama"}, got)
+}
+
+func TestClient_SearchWithInfo(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "srinfo", "totalhits|suggestion|rewrittenquery") {
+			http.Error(w, "invalid srinfo", http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "searchinfo": {
+            "totalhits": 22107,
+            "suggestion": "barack obama",
+            "suggestionsnippet": "barack obama"
+        },
+        "search": [
+            {"ns": 0, "title": "Barack Obama", "pageid": 534366}
+        ]
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.SearchWithInfo(context.TODO(), "barak obama", nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&SearchResult{
+			Hits:       []*SearchResponse{{Title: "Barack Obama", PageID: 534366}},
+			TotalHits:  22107,
+			Suggestion: "barack obama",
+		},
+		got,
+	)
+}
+
+func TestClient_SearchOptions(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		for k, v := range map[string]string{
+			"srnamespace":      "0|14",
+			"srwhat":           "text",
+			"srsort":           "last_edit_desc",
+			"srqiprofile":      "classic",
+			"srenablerewrites": "true",
+			"srprop":           "size|wordcount|timestamp|snippet",
+		} {
+			if !checkQuery(r.Form, k, v) {
+				http.Error(w, "invalid "+k, http.StatusBadRequest)
+				return
+			}
+		}
+		if _, ok := r.Form["limit"]; ok {
+			http.Error(w, "unrecognized parameter: limit", http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.Form["srinterwiki"]; ok {
+			http.Error(w, "unexpected srinterwiki", http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "searchinfo": {"totalhits": 1},
+        "search": [
+            {
+                "ns": 0,
+                "title": "Barack Obama",
+                "pageid": 534366,
+                "size": 346245,
+                "wordcount": 29466,
+                "snippet": "<span class=\"searchmatch\">Barack</span> <span class=\"searchmatch\">Obama</span> II",
+                "timestamp": "2023-07-18T16:26:29Z"
+            }
+        ]
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.Search(context.TODO(), "Barack Obama", &SearchOptions{
+		SrLimit:          10,
+		SrNamespace:      []int{0, 14},
+		SrWhat:           SearchWhatText,
+		SrSort:           SearchSortLastEditDesc,
+		SrQiProfile:      "classic",
+		SrEnableRewrites: true,
+		SrProp:           []SearchProp{SearchPropSize, SearchPropWordCount, SearchPropTimestamp, SearchPropSnippet},
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*SearchResponse{
+			{
+				Title:     "Barack Obama",
+				PageID:    534366,
+				Size:      346245,
+				WordCount: 29466,
+				Snippet:   `<span class="searchmatch">Barack</span> <span class="searchmatch">Obama</span> II`,
+				Timestamp: "2023-07-18T16:26:29Z",
+			},
+		},
 		got,
 	)
 }
+
+func TestClient_SearchInterwiki(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "srinterwiki", "true") {
+			http.Error(w, "invalid srinterwiki", http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "searchinfo": {"totalhits": 1},
+        "search": [
+            {"ns": 0, "title": "Obama (surname)", "pageid": 21169021}
+        ],
+        "interwikisearchinfo": {
+            "wikt": {"totalhits": 1}
+        },
+        "interwikisearch": {
+            "wikt": [
+                {"ns": 0, "title": "wikt:Obama", "snippet": "<span class=\"searchmatch\">Obama</span>"}
+            ]
+        }
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.SearchWithInfo(context.TODO(), "obama", &SearchOptions{SrLimit: 10, SrInterwiki: true})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		map[string][]*SearchResponse{
+			"wikt": {{Title: "wikt:Obama", Snippet: `<span class="searchmatch">Obama</span>`}},
+		},
+		got.Interwiki,
+	)
+}
@@ -0,0 +1,319 @@
+// Package searchquery builds CirrusSearch query strings suitable for the srsearch
+// parameter of the Wikipedia search API.
+// CirrusSearch syntax docs: https://www.mediawiki.org/wiki/Help:CirrusSearch
+package searchquery
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrEmptyValue is returned when a clause is built with an empty value.
+	ErrEmptyValue = errors.New("go-wikipedia: searchquery: empty value")
+	// ErrInvalidCombination is returned when clauses are combined in a way CirrusSearch does not support.
+	ErrInvalidCombination = errors.New("go-wikipedia: searchquery: invalid combination")
+	// ErrInvalidValue is returned when a clause value is out of its accepted range.
+	ErrInvalidValue = errors.New("go-wikipedia: searchquery: invalid value")
+)
+
+type clauseKind int
+
+const (
+	kindText clauseKind = iota
+	kindKeyword
+	kindPreferRecent
+	kindPrefix
+	kindOr
+)
+
+// Clause is a single term of a CirrusSearch query.
+type Clause struct {
+	kind     clauseKind
+	keyword  string
+	value    string
+	negated  bool
+	words    int // the number of terms of a text clause
+	children []Clause
+	err      error
+}
+
+// Words matches pages containing all the given words. The words carrying search syntax,
+// e.g. "intitle:b" or a stray quote, are quoted to be matched literally.
+func Words(words string) Clause {
+	fields := strings.Fields(words)
+	if len(fields) == 0 {
+		return Clause{err: fmt.Errorf("%w: words", ErrEmptyValue)}
+	}
+	for i, w := range fields {
+		if strings.ContainsAny(w, `:"`) || strings.HasPrefix(w, "-") || strings.HasPrefix(w, "!") {
+			fields[i] = quote(w)
+		}
+	}
+	return Clause{kind: kindText, value: strings.Join(fields, " "), words: len(fields)}
+}
+
+// Phrase matches pages containing the exact given phrase.
+func Phrase(phrase string) Clause {
+	if len(strings.TrimSpace(phrase)) == 0 {
+		return Clause{err: fmt.Errorf("%w: phrase", ErrEmptyValue)}
+	}
+	return Clause{kind: kindText, value: quote(phrase), words: 1}
+}
+
+// InTitle matches pages whose title contains the given words.
+func InTitle(title string) Clause {
+	return keyword("intitle", title)
+}
+
+// InTitleRegex matches pages whose title matches the given regular expression.
+func InTitleRegex(re string, caseInsensitive bool) Clause {
+	return regexKeyword("intitle", re, caseInsensitive)
+}
+
+// InCategory matches pages in any of the given categories, without the "Category:" prefix.
+func InCategory(categories ...string) Clause {
+	return keyword("incategory", categories...)
+}
+
+// HasTemplate matches pages transcluding any of the given templates.
+// The "Template:" prefix may be omitted.
+func HasTemplate(templates ...string) Clause {
+	return keyword("hastemplate", templates...)
+}
+
+// InSource matches pages whose wikitext contains the given words.
+func InSource(source string) Clause {
+	return keyword("insource", source)
+}
+
+// InSourceRegex matches pages whose wikitext matches the given regular expression.
+// CirrusSearch regex searches are expensive, they should be combined with another filter.
+func InSourceRegex(re string, caseInsensitive bool) Clause {
+	return regexKeyword("insource", re, caseInsensitive)
+}
+
+// LinksTo matches pages linking to the given title.
+func LinksTo(title string) Clause {
+	return keyword("linksto", title)
+}
+
+// PreferRecent boosts recently edited pages. The boost is the proportion of the score
+// given to recency, between 0 and 1, and halfLife is in days. Zero values use the
+// CirrusSearch defaults.
+func PreferRecent(boost float64, halfLife int) Clause {
+	if boost < 0 || boost > 1 || halfLife < 0 {
+		return Clause{err: fmt.Errorf("%w: prefer-recent: boost %v, half life %d", ErrInvalidValue, boost, halfLife)}
+	}
+
+	var v string
+	switch {
+	case boost > 0 && halfLife > 0:
+		v = strconv.FormatFloat(boost, 'f', -1, 64) + "," + strconv.Itoa(halfLife)
+	case boost > 0:
+		v = strconv.FormatFloat(boost, 'f', -1, 64)
+	case halfLife > 0:
+		return Clause{err: fmt.Errorf("%w: prefer-recent: half life requires a boost", ErrInvalidValue)}
+	}
+	return Clause{kind: kindPreferRecent, keyword: "prefer-recent", value: v}
+}
+
+// Prefix matches pages whose title starts with the given prefix. It is always
+// rendered at the end of the query as CirrusSearch requires.
+func Prefix(prefix string) Clause {
+	if len(strings.TrimSpace(prefix)) == 0 {
+		return Clause{err: fmt.Errorf("%w: prefix", ErrEmptyValue)}
+	}
+	return Clause{kind: kindPrefix, keyword: "prefix", value: prefix}
+}
+
+// Not negates the given clause.
+func Not(c Clause) Clause {
+	c.negated = !c.negated
+	return c
+}
+
+// Or matches pages matching any of the given text clauses.
+// CirrusSearch keyword filters are always combined with AND, and OR binds only the adjacent terms,
+// so only the Words of a single word and Phrase are accepted.
+func Or(clauses ...Clause) Clause {
+	return Clause{kind: kindOr, children: clauses}
+}
+
+func keyword(name string, values ...string) Clause {
+	vs := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 0 {
+		return Clause{err: fmt.Errorf("%w: %s", ErrEmptyValue, name)}
+	}
+
+	v := strings.Join(vs, "|")
+	if len(vs) > 1 || needsQuote(v) {
+		v = quote(v)
+	}
+	return Clause{kind: kindKeyword, keyword: name, value: v}
+}
+
+func regexKeyword(name, re string, caseInsensitive bool) Clause {
+	if len(re) == 0 {
+		return Clause{err: fmt.Errorf("%w: %s regex", ErrEmptyValue, name)}
+	}
+
+	v := "/" + escapeSlashes(re) + "/"
+	if caseInsensitive {
+		v += "i"
+	}
+	return Clause{kind: kindKeyword, keyword: name, value: v}
+}
+
+func needsQuote(s string) bool {
+	return strings.ContainsAny(s, " \t\"():|")
+}
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// escapeSlashes escapes the slashes not already escaped in a regular expression.
+func escapeSlashes(re string) string {
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+	for _, r := range re {
+		if r == '/' && !escaped {
+			b.WriteByte('\\')
+		}
+		escaped = r == '\\' && !escaped
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (c Clause) render(inOr bool) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	switch c.kind {
+	case kindText:
+		return c.renderText()
+	case kindKeyword:
+		if inOr {
+			return "", fmt.Errorf("%w: %s cannot be used in OR", ErrInvalidCombination, c.keyword)
+		}
+		s := c.keyword + ":" + c.value
+		if c.negated {
+			s = "-" + s
+		}
+		return s, nil
+	case kindPreferRecent, kindPrefix:
+		if inOr || c.negated {
+			return "", fmt.Errorf("%w: %s cannot be negated or used in OR", ErrInvalidCombination, c.keyword)
+		}
+		if len(c.value) == 0 {
+			return c.keyword + ":", nil
+		}
+		return c.keyword + ":" + c.value, nil
+	case kindOr:
+		return c.renderOr(inOr)
+	}
+
+	return "", fmt.Errorf("%w: unknown clause", ErrInvalidCombination)
+}
+
+func (c Clause) renderText() (string, error) {
+	if c.negated && c.words > 1 {
+		return "", fmt.Errorf("%w: several words cannot be negated at once, negate each word", ErrInvalidCombination)
+	}
+	if c.negated {
+		return "-" + c.value, nil
+	}
+	return c.value, nil
+}
+
+func (c Clause) renderOr(inOr bool) (string, error) {
+	if inOr || c.negated {
+		return "", fmt.Errorf("%w: OR cannot be negated or nested", ErrInvalidCombination)
+	}
+	if len(c.children) < 2 {
+		return "", fmt.Errorf("%w: OR requires at least two clauses", ErrInvalidCombination)
+	}
+	parts := make([]string, 0, len(c.children))
+	for _, child := range c.children {
+		if child.words > 1 {
+			return "", fmt.Errorf("%w: OR binds single words only, use a Phrase or an Or per word", ErrInvalidCombination)
+		}
+		s, err := child.render(true)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " OR "), nil
+}
+
+// Query is a CirrusSearch query made of clauses combined with AND.
+type Query struct {
+	clauses []Clause
+}
+
+// New returns a new query made of the given clauses.
+func New(clauses ...Clause) *Query {
+	return &Query{clauses: clauses}
+}
+
+// And adds the given clauses to the query.
+func (q *Query) And(clauses ...Clause) *Query {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// Build validates the query and renders it as a srsearch string.
+func (q *Query) Build() (string, error) {
+	var (
+		parts  []string
+		prefix string
+		seen   = make(map[clauseKind]bool)
+	)
+	for _, c := range q.clauses {
+		s, err := c.render(false)
+		if err != nil {
+			return "", err
+		}
+
+		if c.kind == kindPreferRecent || c.kind == kindPrefix {
+			if seen[c.kind] {
+				return "", fmt.Errorf("%w: %s can only be used once", ErrInvalidCombination, c.keyword)
+			}
+			seen[c.kind] = true
+		}
+
+		if c.kind == kindPrefix {
+			prefix = s
+			continue
+		}
+		parts = append(parts, s)
+	}
+
+	if len(prefix) > 0 {
+		parts = append(parts, prefix)
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%w: query", ErrEmptyValue)
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// String renders the query, it returns an empty string if the query is invalid.
+func (q *Query) String() string {
+	s, _ := q.Build()
+	return s
+}
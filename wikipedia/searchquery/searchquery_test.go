@@ -0,0 +1,118 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *Query
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "words and keywords",
+			query: New(Words("barack obama"), InTitle("Obama"), InCategory("Living people")),
+			want:  `barack obama intitle:Obama incategory:"Living people"`,
+		},
+		{
+			name:  "multiple categories and templates",
+			query: New(InCategory("Presidents", "Lawyers"), HasTemplate("Infobox officeholder")),
+			want:  `incategory:"Presidents|Lawyers" hastemplate:"Infobox officeholder"`,
+		},
+		{
+			name:  "phrase escaping",
+			query: New(Phrase(`the "audacity" of hope`)),
+			want:  `"the \"audacity\" of hope"`,
+		},
+		{
+			name:  "regex escaping",
+			query: New(InSource("cite"), InSourceRegex(`https?://a/b\/c`, true)),
+			want:  `insource:cite insource:/https?:\/\/a\/b\/c/i`,
+		},
+		{
+			name:  "negation",
+			query: New(LinksTo("Chicago"), Not(InTitle("list of")), Not(Words("senate"))),
+			want:  `linksto:Chicago -intitle:"list of" -senate`,
+		},
+		{
+			name:  "words escaping",
+			query: New(Words(`a intitle:b -c say"`), InTitle("d")),
+			want:  `a "intitle:b" "-c" "say\"" intitle:d`,
+		},
+		{
+			name:  "negated words",
+			query: New(Not(Words("foo")), Not(Words("bar"))),
+			want:  `-foo -bar`,
+		},
+		{
+			name:  "or and prefix last",
+			query: New(Prefix("Barack"), Or(Words("president"), Phrase("first lady")), PreferRecent(0.6, 160)),
+			want:  `president OR "first lady" prefer-recent:0.6,160 prefix:Barack`,
+		},
+		{
+			name:  "prefer recent defaults",
+			query: New(Words("news")).And(PreferRecent(0, 0)),
+			want:  `news prefer-recent:`,
+		},
+		{
+			name:    "empty query",
+			query:   New(),
+			wantErr: ErrEmptyValue,
+		},
+		{
+			name:    "empty keyword",
+			query:   New(InTitle(" ")),
+			wantErr: ErrEmptyValue,
+		},
+		{
+			name:    "keyword in or",
+			query:   New(Or(Words("a"), InTitle("b"))),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "several words in or",
+			query:   New(Or(Words("barack obama"), Words("joe biden"))),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "single clause or",
+			query:   New(Or(Words("a"))),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "negated prefer recent",
+			query:   New(Words("a"), Not(PreferRecent(0.5, 0))),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "negated several words",
+			query:   New(Not(Words("foo bar"))),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "duplicated prefix",
+			query:   New(Prefix("a"), Prefix("b")),
+			wantErr: ErrInvalidCombination,
+		},
+		{
+			name:    "invalid boost",
+			query:   New(Words("a"), PreferRecent(2, 0)),
+			wantErr: ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Build()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var tagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// Section represents a section of a wikipedia page.
+type Section struct {
+	Title      string     // the plain text heading of the section
+	Level      int        // the heading level, 2 for "== Heading =="
+	TocLevel   int        // the level in the table of contents, 1 for the top-level sections
+	Number     string     // the number in the table of contents, e.g. "1.2"
+	Index      string     // the section index usable with the parse API, e.g. "3" or "T-1" if transcluded
+	Anchor     string     // the anchor of the section in the page URL
+	ByteOffset int        // the byte offset of the heading in the page wikitext
+	Start      int        // the byte offset of the heading in the page content, -1 if not located
+	End        int        // the byte offset of the end of the section and its subsections in the page content
+	Children   []*Section // the subsections
+}
+
+// SectionTree is the hierarchy of the sections of a wikipedia page.
+type SectionTree struct {
+	Sections []*Section // the top-level sections
+}
+
+// Flatten returns all the sections of the tree in document order.
+func (t *SectionTree) Flatten() []*Section {
+	var sections []*Section
+	var walk func([]*Section)
+	walk = func(ss []*Section) {
+		for _, s := range ss {
+			sections = append(sections, s)
+			walk(s.Children)
+		}
+	}
+	walk(t.Sections)
+	return sections
+}
+
+// Locate sets the Start and End byte offsets of the sections into the given page content.
+// The content may be the HTML extract, or the plain text extract with the plain or wiki
+// section format.
+func (t *SectionTree) Locate(content string) {
+	sections := t.Flatten()
+
+	pos := 0
+	for _, s := range sections {
+		s.Start, s.End = -1, -1
+		if start, end := findHeading(content, pos, s); start >= 0 {
+			s.Start = start
+			pos = end
+		}
+	}
+
+	for i, s := range sections {
+		if s.Start < 0 {
+			continue
+		}
+		s.End = len(content)
+		for _, next := range sections[i+1:] {
+			if next.Start >= 0 && next.Level <= s.Level {
+				s.End = next.Start
+				break
+			}
+		}
+	}
+}
+
+// findHeading returns the byte offsets of the heading of the section in the content after pos.
+func findHeading(content string, pos int, s *Section) (start, end int) {
+	rest := content[pos:]
+
+	// HTML extract: <h2><span id="Anchor">Title</span></h2>
+	if i := strings.Index(rest, `id="`+s.Anchor+`"`); len(s.Anchor) > 0 && i >= 0 {
+		if h := strings.LastIndex(rest[:i], "<h"); h >= 0 {
+			end := i
+			if e := strings.Index(rest[i:], "</h"); e >= 0 {
+				end = i + e
+			}
+			return pos + h, pos + end
+		}
+	}
+
+	// plain text extract with the wiki section format: == Title ==
+	marks := strings.Repeat("=", s.Level)
+	if i := strings.Index(rest, marks+" "+s.Title+" "+marks); i >= 0 {
+		return pos + i, pos + i + len(marks+" "+s.Title+" "+marks)
+	}
+
+	// plain text extract with the plain section format: the title on its own line
+	if i := strings.Index(rest, "\n"+s.Title+"\n"); i >= 0 {
+		return pos + i + 1, pos + i + 1 + len(s.Title)
+	}
+
+	return -1, -1
+}
+
+type parseRequest struct {
+	Action  Action   `url:"action" json:"action"`
+	Page    string   `url:"page" json:"page"`
+	Props   []string `url:"prop" del:"|" json:"prop"`
+	Section string   `url:"section,omitempty" json:"section"`
+	Format  string   `url:"format"`
+}
+
+// GetSections returns the section tree of the page with the given title.
+// The Start and End offsets are not set, see SectionTree.Locate.
+func (c *Client) GetSections(ctx context.Context, title string) (*SectionTree, error) {
+	r := &parseRequest{
+		Action: ActionParse,
+		Page:   title,
+		Props:  []string{"sections"},
+		Format: "json",
+	}
+	response, err := c.do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSectionTree(response.Parse.Sections), nil
+}
+
+func newSectionTree(ps []parseSection) *SectionTree {
+	var (
+		tree  = new(SectionTree)
+		stack []*Section
+	)
+	for _, v := range ps {
+		level, _ := strconv.Atoi(v.Level)
+		s := &Section{
+			Title:      html.UnescapeString(tagRegexp.ReplaceAllString(v.Line, "")),
+			Level:      level,
+			TocLevel:   v.TocLevel,
+			Number:     v.Number,
+			Index:      v.Index,
+			Anchor:     v.Anchor,
+			ByteOffset: v.ByteOffset,
+			Start:      -1,
+			End:        -1,
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].Level >= s.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			tree.Sections = append(tree.Sections, s)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, s)
+		}
+		stack = append(stack, s)
+	}
+
+	return tree
+}
+
+// SectionContent represents the content of a section of a wikipedia page.
+type SectionContent struct {
+	Section  *Section // the section, with its offsets into the plain text page content
+	Text     string   // the plain text of the section, including its heading and subsections
+	HTML     string   // the rendered HTML of the section
+	Wikitext string   // the wikitext of the section
+}
+
+// GetSection returns the content of a section of the page with the given title. The section is
+// given either by its index, e.g. "3", or by its title, e.g. "Early life". The index "0" is the lead section.
+func (c *Client) GetSection(ctx context.Context, title, section string) (*SectionContent, error) {
+	tree, err := c.GetSections(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	var s *Section
+	if section == "0" {
+		s = &Section{Index: "0", Start: -1, End: -1}
+	} else {
+		for _, v := range tree.Flatten() {
+			if v.Index == section || strings.EqualFold(v.Title, section) {
+				s = v
+				break
+			}
+		}
+	}
+	if s == nil {
+		return nil, fmt.Errorf("go-wikipedia: section not found: %s", section)
+	}
+
+	r := &pageContentRequest{
+		Action:          ActionQuery,
+		Props:           []string{"extracts", "revisions"},
+		RvProp:          "ids",
+		Titles:          title,
+		ExPlainText:     true,
+		ExSectionFormat: ExtractSectionFormatWiki,
+		Format:          "json",
+		FormatVersion:   2,
+	}
+	response, err := c.do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := response.Query.titlePage(title)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &SectionContent{Section: s}
+	tree.Locate(page.Extract)
+	if s.Index == "0" {
+		s.Start, s.End = 0, len(page.Extract)
+		if first, ok := lo.Find(tree.Flatten(), func(v *Section) bool { return v.Start >= 0 }); ok {
+			s.End = first.Start
+		}
+	}
+	if s.Start >= 0 {
+		sc.Text = strings.TrimSpace(page.Extract[s.Start:s.End])
+	}
+
+	pr := &parseRequest{
+		Action:  ActionParse,
+		Page:    title,
+		Props:   []string{"text", "wikitext"},
+		Section: s.Index,
+		Format:  "json",
+	}
+	response, err = c.do(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	sc.HTML = string(response.Parse.Text)
+	sc.Wikitext = string(response.Parse.Wikitext)
+
+	return sc, nil
+}
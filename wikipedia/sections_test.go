@@ -0,0 +1,196 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetSections(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "action", "parse") {
+			http.Error(w, "invalid action", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "page", "Barack Obama") {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+		if !checkQuery(r.Form, "prop", "sections") {
+			http.Error(w, "invalid prop", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `
+{
+    "parse": {
+        "title": "Barack Obama",
+        "pageid": 534366,
+        "sections": [
+            {
+                "toclevel": 1, "level": "2", "line": "Early life and career", "number": "1",
+                "index": "1", "fromtitle": "Barack_Obama", "byteoffset": 100, "anchor": "Early_life_and_career"
+            },
+            {
+                "toclevel": 2, "level": "3", "line": "<i>Dreams</i> &amp; education", "number": "1.1",
+                "index": "2", "fromtitle": "Barack_Obama", "byteoffset": 200, "anchor": "Dreams_&_education"
+            },
+            {
+                "toclevel": 1, "level": "2", "line": "Presidency", "number": "2",
+                "index": "3", "fromtitle": "Barack_Obama", "byteoffset": 300, "anchor": "Presidency"
+            }
+        ]
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetSections(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Len(t, got.Sections, 2)
+	require.Equal(t, "Early life and career", got.Sections[0].Title)
+	require.Equal(t, "Presidency", got.Sections[1].Title)
+	require.Len(t, got.Sections[0].Children, 1)
+
+	child := got.Sections[0].Children[0]
+	require.Equal(t, "Dreams & education", child.Title)
+	require.Equal(t, 3, child.Level)
+	require.Equal(t, 2, child.TocLevel)
+	require.Equal(t, "1.1", child.Number)
+	require.Equal(t, "Dreams_&_education", child.Anchor)
+	require.Equal(t, 200, child.ByteOffset)
+	require.Equal(
+		t,
+		[]string{"Early life and career", "Dreams & education", "Presidency"},
+		lo.Map(got.Flatten(), func(s *Section, _ int) string { return s.Title }),
+	)
+}
+
+func TestSectionTree_Locate(t *testing.T) {
+	tree := newSectionTree([]parseSection{
+		{Level: "2", Line: "Early life", Anchor: "Early_life"},
+		{Level: "3", Line: "Education", Anchor: "Education"},
+		{Level: "2", Line: "Presidency", Anchor: "Presidency"},
+		{Level: "2", Line: "Missing", Anchor: "Missing"},
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		want    [][2]int
+	}{
+		{
+			name:    "wiki section format",
+			content: "Lead.\n\n== Early life ==\nBorn.\n\n=== Education ===\nSchool.\n\n== Presidency ==\nElected.",
+			want:    [][2]int{{7, 58}, {31, 58}, {58, 83}, {-1, -1}},
+		},
+		{
+			name:    "plain section format",
+			content: "Lead.\nEarly life\nBorn.\nEducation\nSchool.\nPresidency\nElected.",
+			want:    [][2]int{{6, 41}, {23, 41}, {41, 60}, {-1, -1}},
+		},
+		{
+			name: "html",
+			content: `<p>Lead.</p><h2><span id="Early_life">Early life</span></h2><p>Born.</p>` +
+				`<h3><span id="Education">Education</span></h3><h2><span id="Presidency">Presidency</span></h2>`,
+			want: [][2]int{{12, 118}, {72, 118}, {118, 166}, {-1, -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree.Locate(tt.content)
+			got := lo.Map(tree.Flatten(), func(s *Section, _ int) [2]int { return [2]int{s.Start, s.End} })
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_GetSection(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		switch {
+		case checkQuery(r.Form, "action", "parse") && checkQuery(r.Form, "prop", "sections"):
+			fmt.Fprint(w, `
+{
+    "parse": {
+        "title": "Barack Obama",
+        "pageid": 534366,
+        "sections": [
+            {"toclevel": 1, "level": "2", "line": "Early life", "number": "1", "index": "1", "anchor": "Early_life"},
+            {"toclevel": 1, "level": "2", "line": "Presidency", "number": "2", "index": "2", "anchor": "Presidency"}
+        ]
+    }
+}`)
+		case checkQuery(r.Form, "action", "query"):
+			if !checkQuery(r.Form, "explaintext", "true") || !checkQuery(r.Form, "exsectionformat", "wiki") {
+				http.Error(w, "invalid extract format", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "batchcomplete": "",
+    "query": {
+        "pages": {
+            "534366": {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "extract": "Lead.\n\n== Early life ==\nBorn in Honolulu.\n\n== Presidency ==\nElected."
+            }
+        }
+    }
+}`)
+		case checkQuery(r.Form, "action", "parse") && checkQuery(r.Form, "prop", "text|wikitext"):
+			if !checkQuery(r.Form, "section", "1") {
+				http.Error(w, "invalid section", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `
+{
+    "parse": {
+        "title": "Barack Obama",
+        "pageid": 534366,
+        "text": {"*": "<h2>Early life</h2><p>Born in Honolulu.</p>"},
+        "wikitext": {"*": "== Early life ==\nBorn in [[Honolulu]]."}
+    }
+}`)
+		default:
+			http.Error(w, "invalid request", http.StatusBadRequest)
+		}
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetSection(context.TODO(), "Barack Obama", "early life")
+	require.NoError(t, err)
+	require.Equal(t, "1", got.Section.Index)
+	require.Equal(t, "== Early life ==\nBorn in Honolulu.", got.Text)
+	require.Equal(t, "<h2>Early life</h2><p>Born in Honolulu.</p>", got.HTML)
+	require.Equal(t, "== Early life ==\nBorn in [[Honolulu]].", got.Wikitext)
+
+	_, err = c.GetSection(context.TODO(), "Barack Obama", "Legacy")
+	require.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package wikipedia
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/anaskhan96/soup"
+	"github.com/samber/lo"
+	"golang.org/x/net/html"
+)
+
+const maxSpan = 1000 // the max rowspan and colspan of a table cell, as enforced by MediaWiki
+
+// TableCell represents a cell of a wikipedia table.
+type TableCell struct {
+	Text   string   // the plain text of the cell, without footnote markers
+	Links  []string // the titles of the pages linked from the cell
+	Header bool     // whether the cell is a header cell
+}
+
+// Table represents a wikitable of a wikipedia page. The row and column spans are expanded,
+// a cell spanning several rows or columns is repeated in each of them.
+type Table struct {
+	Caption string
+	Header  [][]*TableCell // the header rows
+	Rows    [][]*TableCell // the body rows
+}
+
+// WriteCSV writes the header and body rows of the table as CSV.
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	for _, row := range append(t.Header, t.Rows...) {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell != nil {
+				record[i] = cell.Text
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// GetTables returns all the wikitables of the page with the given title.
+func (c *Client) GetTables(ctx context.Context, title string) ([]*Table, error) {
+	text, err := c.GetPageHTML(ctx, title, &HTMLOptions{StripEditLinks: true})
+	if err != nil {
+		return nil, err
+	}
+
+	doc := soup.HTMLParse(text)
+	if doc.Error != nil {
+		return nil, doc.Error
+	}
+
+	var tables []*Table
+	for _, t := range doc.FindAll("table", "class", "wikitable") {
+		tables = append(tables, newTable(t.Pointer))
+	}
+	return tables, nil
+}
+
+func newTable(n *html.Node) *Table {
+	t := new(Table)
+
+	var rows []*html.Node
+	for _, child := range elementChildren(n) {
+		switch child.Data {
+		case "caption":
+			t.Caption = nodeText(child)
+		case "thead", "tbody", "tfoot":
+			for _, tr := range elementChildren(child) {
+				if tr.Data == "tr" {
+					rows = append(rows, tr)
+				}
+			}
+		case "tr":
+			rows = append(rows, child)
+		}
+	}
+
+	grid, isHeader := tableGrid(rows)
+	for r := range rows {
+		width := 0
+		for col := range grid[r] {
+			width = max(width, col+1)
+		}
+		row := make([]*TableCell, width)
+		for col, cell := range grid[r] {
+			row[col] = cell
+		}
+		if isHeader[r] && len(t.Rows) == 0 {
+			t.Header = append(t.Header, row)
+		} else {
+			t.Rows = append(t.Rows, row)
+		}
+	}
+
+	return t
+}
+
+// tableGrid lays the cells of the given rows out on a grid, repeating the spanned cells, and
+// returns the grid with whether each row is a header row.
+func tableGrid(rows []*html.Node) (map[int]map[int]*TableCell, []bool) {
+	grid := make(map[int]map[int]*TableCell)
+	isHeader := make([]bool, len(rows))
+	for r, tr := range rows {
+		col := 0
+		isHeader[r] = tr.Parent != nil && tr.Parent.Data == "thead"
+		allHeaders := true
+		for _, td := range elementChildren(tr) {
+			if td.Data != "td" && td.Data != "th" {
+				continue
+			}
+			cell := &TableCell{Text: nodeText(td), Links: nodeLinks(td), Header: td.Data == "th"}
+			allHeaders = allHeaders && cell.Header
+
+			for grid[r] != nil && grid[r][col] != nil {
+				col++
+			}
+			rowspan, colspan := spanAttr(td, "rowspan"), spanAttr(td, "colspan")
+			for i := r; i < r+rowspan && i < len(rows); i++ {
+				if grid[i] == nil {
+					grid[i] = make(map[int]*TableCell)
+				}
+				for j := col; j < col+colspan; j++ {
+					grid[i][j] = cell
+				}
+			}
+			col += colspan
+		}
+		isHeader[r] = isHeader[r] || (allHeaders && len(grid[r]) > 0)
+	}
+	return grid, isHeader
+}
+
+func elementChildren(n *html.Node) []*html.Node {
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	return lo.Contains(strings.Fields(attr(n, "class")), class)
+}
+
+func spanAttr(n *html.Node, key string) int {
+	v, err := strconv.Atoi(strings.TrimSpace(attr(n, key)))
+	if err != nil || v < 1 {
+		return 1
+	}
+	return min(v, maxSpan)
+}
+
+// nodeText returns the displayed text of the node, without the footnote markers and the hidden elements.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			switch {
+			case n.Data == "style" || n.Data == "script",
+				n.Data == "sup" && hasClass(n, "reference"),
+				strings.Contains(strings.ReplaceAll(attr(n, "style"), " ", ""), "display:none"):
+				return
+			case n.Data == "br":
+				b.WriteString(" ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(b.String(), " "))
+}
+
+// nodeLinks returns the titles of the wiki pages linked from the node.
+func nodeLinks(n *html.Node) []string {
+	var links []string
+	for _, a := range (soup.Root{Pointer: n}).FindAll("a") {
+		href, title := attr(a.Pointer, "href"), attr(a.Pointer, "title")
+		if strings.HasPrefix(href, "/wiki/") && len(title) > 0 && !lo.Contains(links, title) {
+			links = append(links, title)
+		}
+	}
+	return links
+}
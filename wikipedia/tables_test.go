@@ -0,0 +1,82 @@
+package wikipedia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetTables(t *testing.T) {
+	text, err := json.Marshal(`<div class="mw-parser-output">
+<table class="wikitable sortable"><caption>Presidential elections</caption>
+<tbody><tr><th rowspan="2">Year</th><th colspan="2">Votes</th></tr>
+<tr><th>Popular</th><th>Electoral</th></tr>
+<tr><td rowspan="2"><a href="/wiki/2008_United_States_presidential_election"
+title="2008 United States presidential election">2008</a></td>
+<td>69,498,516<sup class="reference"><a href="#cite_note-1">[1]</a></sup></td><td>365</td></tr>
+<tr><td colspan="2">Re-<br>counted</td></tr>
+</tbody></table>
+<table class="infobox"><tbody><tr><td>Ignored</td></tr></tbody></table>
+</div>`)
+	require.NoError(t, err)
+
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		if !checkQuery(r.Form, "action", "parse") || !checkQuery(r.Form, "page", "Barack Obama") {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"parse": {"title": "Barack Obama", "pageid": 534366, "text": {"*": %s}}}`, text)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetTables(context.TODO(), "Barack Obama")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	var (
+		year      = &TableCell{Text: "Year", Header: true}
+		votes     = &TableCell{Text: "Votes", Header: true}
+		election  = &TableCell{Text: "2008", Links: []string{"2008 United States presidential election"}}
+		recounted = &TableCell{Text: "Re- counted"}
+	)
+	require.Equal(
+		t,
+		&Table{
+			Caption: "Presidential elections",
+			Header: [][]*TableCell{
+				{year, votes, votes},
+				{year, {Text: "Popular", Header: true}, {Text: "Electoral", Header: true}},
+			},
+			Rows: [][]*TableCell{
+				{election, {Text: "69,498,516"}, {Text: "365"}},
+				{election, recounted, recounted},
+			},
+		},
+		got[0],
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, got[0].WriteCSV(&buf))
+	require.Equal(
+		t,
+		"Year,Votes,Votes\nYear,Popular,Electoral\n2008,\"69,498,516\",365\n2008,Re- counted,Re- counted\n",
+		buf.String(),
+	)
+}
@@ -1,12 +1,14 @@
 package wikipedia
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/google/go-querystring/query"
 	"github.com/samber/lo"
@@ -17,11 +19,13 @@ type Action string
 
 const (
 	ActionQuery Action = "query" // query action
+	ActionParse Action = "parse" // parse action
 )
 
 const (
 	urlWithPlaceholder = "http://%s.wikipedia.org/w/api.php"
 	defaultLimit       = 10
+	maxTitles          = 50 // the max number of titles per request
 )
 
 // Client is a client for the Wikipedia API requests.
@@ -48,6 +52,38 @@ func NewClient(opts ...Option) (*Client, error) {
 	}, nil
 }
 
+// apiBool is a boolean flag of the API response, given as an empty string when set
+// in the format version 1 and as a boolean in the format version 2.
+type apiBool bool
+
+func (b *apiBool) UnmarshalJSON(data []byte) error {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		*b = apiBool(v)
+		return nil
+	}
+	*b = true
+	return nil
+}
+
+// continueValue is a continuation value of the API response, given either as a
+// string or as a number depending on the module.
+type continueValue string
+
+func (v *continueValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = continueValue(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*v = continueValue(n)
+	return nil
+}
+
 type requestError struct {
 	Code string `json:"code"`
 	Info string `json:"info"`
@@ -58,83 +94,303 @@ type warnings struct {
 }
 
 type searchInfo struct {
-	TotalHits int `json:"totalhits"`
+	TotalHits      int    `json:"totalhits"`
+	Suggestion     string `json:"suggestion"`
+	RewrittenQuery string `json:"rewrittenquery"`
+}
+
+type revisionSlot struct {
+	ContentModel  string `json:"contentmodel"`
+	ContentFormat string `json:"contentformat"`
+	Star          string `json:"*"`
+	Content       string `json:"content"`
 }
 
 type revision struct {
-	RevID    int    `json:"revid"`
-	ParentID int    `json:"parentid"`
-	Star     string `json:"*"`
+	RevID     int                     `json:"revid"`
+	ParentID  int                     `json:"parentid"`
+	Minor     apiBool                 `json:"minor"`
+	User      string                  `json:"user"`
+	Timestamp string                  `json:"timestamp"`
+	Size      int                     `json:"size"`
+	SHA1      string                  `json:"sha1"`
+	Comment   string                  `json:"comment"`
+	Star      string                  `json:"*"`
+	Slots     map[string]revisionSlot `json:"slots"`
 }
 
 type innerPage struct {
-	Ns                  int                 `json:"ns"`
-	Title               string              `json:"title"`
-	PageID              int                 `json:"pageid"`
-	ContentModel        string              `json:"contentmodel"`
-	PageLanguage        string              `json:"pagelanguage"`
-	PageLanguageTmlCode string              `json:"pagelanguagetmlcode"`
-	PageLanguageDir     string              `json:"pagelanguagedir"`
-	Touched             string              `json:"touched"`
-	LastRevid           int                 `json:"lastrevid"`
-	Length              int                 `json:"length"`
-	FullURL             string              `json:"fullurl"`
-	EditURL             string              `json:"editurl"`
-	CanonicalURL        string              `json:"canonicalurl"`
-	PageProps           map[string]string   `json:"pageprops"`
-	Missing             string              `json:"missing"`
-	Extract             string              `json:"extract"`
-	Revisions           []revision          `json:"revisions"`
-	Extlink             []map[string]string `json:"extlinks"`
-	Link                []map[string]any    `json:"links"`
-	Category            []map[string]any    `json:"categories"`
-	ImageInfo           []map[string]string `json:"imageinfo"`
-	Coordinate          []map[string]any    `json:"coordinates"`
+	Ns                  int               `json:"ns"`
+	Title               string            `json:"title"`
+	PageID              int               `json:"pageid"`
+	ContentModel        string            `json:"contentmodel"`
+	PageLanguage        string            `json:"pagelanguage"`
+	PageLanguageTmlCode string            `json:"pagelanguagetmlcode"`
+	PageLanguageDir     string            `json:"pagelanguagedir"`
+	Touched             string            `json:"touched"`
+	LastRevid           int               `json:"lastrevid"`
+	Length              int               `json:"length"`
+	FullURL             string            `json:"fullurl"`
+	EditURL             string            `json:"editurl"`
+	CanonicalURL        string            `json:"canonicalurl"`
+	PageProps           map[string]string `json:"pageprops"`
+	Missing             apiBool           `json:"missing"`
+	Invalid             apiBool           `json:"invalid"`
+	InvalidReason       string            `json:"invalidreason"`
+	Special             apiBool           `json:"special"`
+	Redirect            apiBool           `json:"redirect"`
+	Extract             string            `json:"extract"`
+	Revisions           []revision        `json:"revisions"`
+	Extlink             []extlink         `json:"extlinks"`
+	Link                []link            `json:"links"`
+	Category            []category        `json:"categories"`
+	Images              []link            `json:"images"`
+	ImageInfo           []imageInfo       `json:"imageinfo"`
+	Coordinate          []coordinate      `json:"coordinates"`
+}
+
+type extlink struct {
+	Star string `json:"*"`
+	URL  string `json:"url"`
+}
+
+type extMetadata struct {
+	Value any `json:"value"`
+}
+
+type imageInfo struct {
+	URL            string                 `json:"url"`
+	DescriptionURL string                 `json:"descriptionurl"`
+	ThumbURL       string                 `json:"thumburl"`
+	ThumbWidth     int                    `json:"thumbwidth"`
+	ThumbHeight    int                    `json:"thumbheight"`
+	Mime           string                 `json:"mime"`
+	Width          int                    `json:"width"`
+	Height         int                    `json:"height"`
+	Size           int                    `json:"size"`
+	ExtMetadata    map[string]extMetadata `json:"extmetadata"`
+}
+
+type coordinate struct {
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Primary apiBool `json:"primary"`
+	Globe   string  `json:"globe"`
+	Type    string  `json:"type"`
+	Name    string  `json:"name"`
+	Dim     int     `json:"dim"`
+	Country string  `json:"country"`
+	Region  string  `json:"region"`
+}
+
+type link struct {
+	Ns    int    `json:"ns"`
+	Title string `json:"title"`
+}
+
+type category struct {
+	Ns            int     `json:"ns"`
+	Title         string  `json:"title"`
+	SortKey       string  `json:"sortkey"`
+	SortKeyPrefix string  `json:"sortkeyprefix"`
+	Timestamp     string  `json:"timestamp"`
+	Hidden        apiBool `json:"hidden"`
 }
 
 type normalize struct {
-	From string `json:"from"`
-	To   string `json:"to"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	ToFragment string `json:"tofragment"`
+}
+
+type badRevision struct {
+	RevID   int     `json:"revid"`
+	Missing apiBool `json:"missing"`
+}
+
+// badRevisionList is the missing revisions of the API response, given as an array or as an
+// object keyed by revision id.
+type badRevisionList []badRevision
+
+func (l *badRevisionList) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(data, (*[]badRevision)(l))
+	}
+
+	var revs map[string]badRevision
+	if err := json.Unmarshal(data, &revs); err != nil {
+		return err
+	}
+	*l = lo.Values(revs)
+	return nil
+}
+
+// pageList is the pages of the API response, given as an array in the format version 2 and as
+// an object keyed by page id in the format version 1, decoded in the numeric order of the ids.
+type pageList []innerPage
+
+func (l *pageList) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var pages []innerPage
+		if err := json.Unmarshal(data, &pages); err != nil {
+			return err
+		}
+		*l = pages
+		return nil
+	}
+
+	var pages map[string]innerPage
+	if err := json.Unmarshal(data, &pages); err != nil {
+		return err
+	}
+	ids := lo.Keys(pages)
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA != nil || errB != nil {
+			return ids[i] < ids[j]
+		}
+		return a < b
+	})
+	*l = lo.Map(ids, func(id string, _ int) innerPage { return pages[id] })
+	return nil
 }
 
 type responseQuery struct {
-	Search     []*SearchResponse    `json:"search"`
-	SearchInfo searchInfo           `json:"searchinfo"`
-	Pages      map[string]innerPage `json:"pages"`
-	Redirect   []normalize          `json:"redirects"`
-	Normalize  []normalize          `json:"normalized"`
+	Search          []*SearchResponse            `json:"search"`
+	SearchInfo      searchInfo                   `json:"searchinfo"`
+	InterwikiSearch map[string][]*SearchResponse `json:"interwikisearch"`
+	Pages           pageList                     `json:"pages"`
+	Redirect        []normalize                  `json:"redirects"`
+	Normalize       []normalize                  `json:"normalized"`
+	BadRevIDs       badRevisionList              `json:"badrevids"`
 }
 
-func (rq *responseQuery) oneOfPage() (innerPage, error) {
-	pageIDs := lo.Keys(rq.Pages)
-	if len(pageIDs) == 0 {
-		return innerPage{}, errors.New("go-wikipedia: no pages found")
+// findPage returns the page of the response matching the given title, or the given id if the
+// title is empty.
+func (rq *responseQuery) findPage(id int, title string) (innerPage, error) {
+	page, ok := lo.Find(rq.Pages, func(p innerPage) bool {
+		if len(title) > 0 {
+			return p.Title == title
+		}
+		return p.PageID == id
+	})
+	if !ok {
+		return innerPage{}, &MissingPageError{ID: id, Title: title}
 	}
+	return page, checkPage(page, id, title)
+}
 
-	return rq.Pages[pageIDs[0]], nil
+// titlePage returns the page of the response matching the given requested title, after its normalization.
+func (rq *responseQuery) titlePage(title string) (innerPage, error) {
+	if n, ok := lo.Find(rq.Normalize, func(n normalize) bool { return n.From == title }); ok {
+		title = n.To
+	}
+	return rq.findPage(0, title)
+}
+
+// revisionPage returns the page of the response carrying the given revision.
+func (rq *responseQuery) revisionPage(revID int) (innerPage, error) {
+	if lo.ContainsBy(rq.BadRevIDs, func(r badRevision) bool { return r.RevID == revID }) {
+		return innerPage{}, &MissingRevisionError{RevID: revID}
+	}
+	page, ok := lo.Find(rq.Pages, func(p innerPage) bool {
+		return lo.ContainsBy(p.Revisions, func(r revision) bool { return r.RevID == revID })
+	})
+	if !ok {
+		return innerPage{}, &MissingRevisionError{RevID: revID}
+	}
+	return page, checkPage(page, page.PageID, page.Title)
+}
+
+// checkPage returns the error of a page returned without content.
+func checkPage(page innerPage, id int, title string) error {
+	switch {
+	case bool(page.Invalid):
+		return &InvalidTitleError{Title: page.Title, Reason: page.InvalidReason}
+	case bool(page.Special):
+		return &SpecialPageError{Title: page.Title}
+	case bool(page.Missing):
+		return &MissingPageError{ID: id, Title: title}
+	}
+	return nil
 }
 
 type SearchResponse struct {
-	Ns        int    `json:"ns"`
-	Title     string `json:"title"`
-	PageID    int    `json:"pageid"`
-	Size      int    `json:"size"`
-	WordCount int    `json:"wordcount"`
-	Snippet   string `json:"snippet"`
-	Timestamp string `json:"timestamp"`
+	Ns              int    `json:"ns"`
+	Title           string `json:"title"`
+	PageID          int    `json:"pageid"`
+	Size            int    `json:"size"`
+	WordCount       int    `json:"wordcount"`
+	Snippet         string `json:"snippet"`
+	Timestamp       string `json:"timestamp"`
+	TitleSnippet    string `json:"titlesnippet"`
+	RedirectTitle   string `json:"redirecttitle"`
+	RedirectSnippet string `json:"redirectsnippet"`
+	SectionTitle    string `json:"sectiontitle"`
+	SectionSnippet  string `json:"sectionsnippet"`
+	CategorySnippet string `json:"categorysnippet"`
+	IsFileMatch     bool   `json:"isfilematch"`
 }
 
 type responseContinue struct {
-	Sroffset int    `json:"sroffset"`
-	Continue string `json:"continue"`
+	Sroffset   int           `json:"sroffset"`
+	PlContinue string        `json:"plcontinue"`
+	ClContinue string        `json:"clcontinue"`
+	ElContinue continueValue `json:"elcontinue"`
+	ImContinue string        `json:"imcontinue"`
+	IiContinue string        `json:"iicontinue"`
+	CoContinue string        `json:"cocontinue"`
+	Continue   string        `json:"continue"`
+}
+
+type parseSection struct {
+	TocLevel   int    `json:"toclevel"`
+	Level      string `json:"level"`
+	Line       string `json:"line"`
+	Number     string `json:"number"`
+	Index      string `json:"index"`
+	FromTitle  string `json:"fromtitle"`
+	ByteOffset int    `json:"byteoffset"`
+	Anchor     string `json:"anchor"`
+}
+
+// parseText is a text of the parse response, given as an object with a "*" key in the
+// format version 1 and as a string in the format version 2.
+type parseText string
+
+func (t *parseText) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = parseText(s)
+		return nil
+	}
+	var v struct {
+		Star string `json:"*"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*t = parseText(v.Star)
+	return nil
+}
+
+type responseParse struct {
+	Title    string         `json:"title"`
+	PageID   int            `json:"pageid"`
+	RevID    int            `json:"revid"`
+	Text     parseText      `json:"text"`
+	Wikitext parseText      `json:"wikitext"`
+	Sections []parseSection `json:"sections"`
 }
 
 type apiResult struct {
 	Error         requestError     `json:"error"`
 	Warnings      warnings         `json:"warnings"`
-	BatchComplete string           `json:"batchcomplete"`
+	BatchComplete apiBool          `json:"batchcomplete"`
 	Continue      responseContinue `json:"continue"`
 	Query         responseQuery    `json:"query"`
+	Parse         responseParse    `json:"parse"`
 }
 
 func (c *Client) do(ctx context.Context, v any) (*apiResult, error) {
@@ -166,7 +422,7 @@ func (c *Client) do(ctx context.Context, v any) (*apiResult, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("go-wikipedia: http request: %s", resp.Status)
+		return nil, &APIError{StatusCode: resp.StatusCode, RequestID: resp.Header.Get("X-Request-Id")}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -180,8 +436,35 @@ func (c *Client) do(ctx context.Context, v any) (*apiResult, error) {
 	}
 
 	if len(res.Error.Code) > 0 {
-		return nil, fmt.Errorf("go-wikipedia: returns error, code: %s, info: %s", res.Error.Code, res.Error.Info)
+		return nil, &APIError{
+			Code:       res.Error.Code,
+			Info:       res.Error.Info,
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("X-Request-Id"),
+		}
 	}
 
 	return res, nil
 }
+
+// continuedRequest is a request whose results are returned across several batches.
+type continuedRequest interface {
+	// setContinue sets the continuation of the next batch, and returns false when there is none.
+	setContinue(rc responseContinue) bool
+}
+
+// doContinue does the request and its continuations, calling fn with the response of each batch.
+func (c *Client) doContinue(ctx context.Context, r continuedRequest, fn func(*apiResult) error) error {
+	for {
+		response, err := c.do(ctx, r)
+		if err != nil {
+			return err
+		}
+		if err := fn(response); err != nil {
+			return err
+		}
+		if !r.setContinue(response.Continue) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package wikipedia
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+)
+
+// Revision represents a revision of a wikipedia page.
+type Revision struct {
+	RevisionID int    // the revision id
+	ParentID   int    // the id of the previous revision, 0 for a page creation
+	Minor      bool   // whether the revision is flagged as a minor edit
+	User       string // the name of the user who made the revision
+	Timestamp  string // the time of the revision
+	Size       int    // the size of the revision in bytes
+	SHA1       string // the SHA-1 hash of the revision content
+	Comment    string // the edit summary
+}
+
+// Wikitext represents the wikitext source of a wikipedia page revision.
+type Wikitext struct {
+	PageID       int
+	Title        string
+	Content      string    // the wikitext source
+	ContentModel string    // the content model, "wikitext" for articles
+	Revision     *Revision // the revision of the content
+}
+
+type wikitextRequest struct {
+	Action        Action   `url:"action" json:"action"`
+	Props         []string `url:"prop" del:"|" json:"prop"`
+	PageIDs       []int    `url:"pageids,omitempty" del:"|" json:"pageids"`
+	Titles        []string `url:"titles,omitempty" del:"|" json:"titles"`
+	RevIDs        []int    `url:"revids,omitempty" del:"|" json:"revids"`
+	RvProp        []string `url:"rvprop" del:"|" json:"rvprop"`
+	RvSlots       string   `url:"rvslots" json:"rvslots"`
+	Format        string   `url:"format"`
+	FormatVersion int      `url:"formatversion,omitempty"`
+}
+
+func newWikitextRequest() *wikitextRequest {
+	return &wikitextRequest{
+		Action:        ActionQuery,
+		Props:         []string{"revisions"},
+		RvProp:        []string{"ids", "flags", "timestamp", "user", "size", "sha1", "comment", "content"},
+		RvSlots:       "main",
+		Format:        "json",
+		FormatVersion: 2,
+	}
+}
+
+// GetWikitext returns the wikitext of the current revision of the page with the given id.
+func (c *Client) GetWikitext(ctx context.Context, id int) (*Wikitext, error) {
+	r := newWikitextRequest()
+	r.PageIDs = []int{id}
+	return c.wikitext(ctx, r)
+}
+
+// GetWikitextByTitle returns the wikitext of the current revision of the page with the given title.
+func (c *Client) GetWikitextByTitle(ctx context.Context, title string) (*Wikitext, error) {
+	r := newWikitextRequest()
+	r.Titles = []string{title}
+	return c.wikitext(ctx, r)
+}
+
+// GetWikitextByRevision returns the wikitext of the given revision.
+func (c *Client) GetWikitextByRevision(ctx context.Context, revID int) (*Wikitext, error) {
+	r := newWikitextRequest()
+	r.RevIDs = []int{revID}
+	return c.wikitext(ctx, r)
+}
+
+func (c *Client) wikitext(ctx context.Context, r *wikitextRequest) (*Wikitext, error) {
+	response, err := c.do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var page innerPage
+	switch {
+	case len(r.RevIDs) > 0:
+		page, err = response.Query.revisionPage(r.RevIDs[0])
+	case len(r.Titles) > 0:
+		page, err = response.Query.titlePage(r.Titles[0])
+	default:
+		page, err = response.Query.findPage(r.PageIDs[0], "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Revisions) == 0 {
+		return nil, &MissingRevisionError{Title: page.Title}
+	}
+
+	rev := page.Revisions[0]
+	slot, ok := rev.Slots["main"]
+	if !ok {
+		slot = revisionSlot{ContentModel: page.ContentModel, Star: rev.Star}
+	}
+
+	return &Wikitext{
+		PageID:       page.PageID,
+		Title:        page.Title,
+		Content:      lo.Ternary(len(slot.Content) > 0, slot.Content, slot.Star),
+		ContentModel: slot.ContentModel,
+		Revision: &Revision{
+			RevisionID: rev.RevID,
+			ParentID:   rev.ParentID,
+			Minor:      bool(rev.Minor),
+			User:       rev.User,
+			Timestamp:  rev.Timestamp,
+			Size:       rev.Size,
+			SHA1:       rev.SHA1,
+			Comment:    rev.Comment,
+		},
+	}, nil
+}
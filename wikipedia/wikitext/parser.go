@@ -0,0 +1,645 @@
+package wikitext
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	refOpenRegexp      = regexp.MustCompile(`(?is)<ref(\s[^<>]*?)?(/?)>`)
+	refCloseRegexp     = regexp.MustCompile(`(?i)</ref\s*>`)
+	nowikiCloseRegexp  = regexp.MustCompile(`(?i)</nowiki>`)
+	commentCloseRegexp = regexp.MustCompile(`-->`)
+	attrRegexp         = regexp.MustCompile(`(\w+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'/>]+))`)
+	extLinkRegexp      = regexp.MustCompile(`(?i)^\[(https?://|ftp://|//|mailto:)`)
+	filePrefixRegexp   = regexp.MustCompile(`(?i)^\s*(file|image)\s*:`)
+	sizeOptionRegexp   = regexp.MustCompile(`^\d*(x\d+)?px$`)
+)
+
+// fileOptions are the display options of a file which are not a caption.
+var fileOptions = map[string]bool{
+	"thumb": true, "thumbnail": true, "frame": true, "framed": true, "frameless": true, "border": true,
+	"left": true, "right": true, "center": true, "centre": true, "none": true, "upright": true,
+	"baseline": true, "middle": true, "sub": true, "super": true, "top": true, "text-top": true,
+	"bottom": true, "text-bottom": true,
+}
+
+// Parse parses the wikitext into a syntax tree. Parsing never fails, malformed
+// markup is kept as text.
+func Parse(src string) *Document {
+	p := newParser(strings.ReplaceAll(src, "\r\n", "\n"))
+	return &Document{Nodes: p.parseBlocks(0, len(p.src))}
+}
+
+// span is the [start, end) byte offsets of a part of the source.
+type span struct {
+	start, end int
+}
+
+// parser parses the spans of a source, sharing the ends of the nested constructs and the
+// closing tags found in the source so that no span is scanned again by the enclosing ones.
+type parser struct {
+	src       string
+	ends      []int            // the end of the construct starting at each offset, see end
+	closes    map[string][]int // the offset following the closer found from each offset, by closer
+	tableEnds map[int]int      // the end of the table starting at each line offset, see tableEnd
+	refOpens  map[int][]int    // the submatches of the ref opening tags, by offset
+	refCloses [][]int          // the matches of the ref closing tags
+	nowikis   [][]int          // the matches of the nowiki closing tags
+	comments  [][]int          // the matches of the comment closers
+	linkEnds  []int            // the offsets of the "]" and "\n" ending the external links
+	visits    int              // the number of offsets visited by the scans, linear in the source length
+}
+
+const unknownEnd = -2
+
+func newParser(src string) *parser {
+	p := &parser{
+		src:       src,
+		ends:      newEnds(len(src)),
+		closes:    make(map[string][]int, 3),
+		tableEnds: make(map[int]int),
+		refOpens:  make(map[int][]int),
+		refCloses: refCloseRegexp.FindAllStringIndex(src, -1),
+		nowikis:   nowikiCloseRegexp.FindAllStringIndex(src, -1),
+		comments:  commentCloseRegexp.FindAllStringIndex(src, -1),
+	}
+	// the opening tags cannot contain one another, their leftmost matches are all of them
+	for _, m := range refOpenRegexp.FindAllStringSubmatchIndex(src, -1) {
+		p.refOpens[m[0]] = m
+	}
+	for i := 0; i < len(src); i++ {
+		if src[i] == ']' || src[i] == '\n' {
+			p.linkEnds = append(p.linkEnds, i)
+		}
+	}
+	return p
+}
+
+func (p *parser) parseBlocks(i, j int) []Node {
+	var (
+		nodes []Node
+		para  *span
+	)
+	flush := func() {
+		if para != nil {
+			if inline := p.parseInline(para.start, para.end); len(inline) > 0 {
+				nodes = append(nodes, &Paragraph{Nodes: inline})
+			}
+			para = nil
+		}
+	}
+
+	for k := i; k < j; {
+		e := p.lineEnd(k, j)
+		line := p.src[k:e]
+		start, end := p.trim(k, e)
+		trimmed := p.src[start:end]
+		switch {
+		case len(trimmed) == 0:
+			flush()
+		case isHeading(trimTrailingComments(trimmed)):
+			flush()
+			nodes = append(nodes, p.parseHeading(start, start+len(trimTrailingComments(trimmed))))
+		case strings.HasPrefix(trimmed, "{|"):
+			flush()
+			e = p.tableEnd(k, j)
+			nodes = append(nodes, p.parseTable(k, e))
+		case strings.ContainsAny(line[:1], "*#:;"):
+			flush()
+			var list *List
+			list, e = p.parseList(k, j)
+			nodes = append(nodes, list)
+		case strings.HasPrefix(line, "----"):
+			flush()
+			nodes = append(nodes, &HorizontalRule{})
+		case para == nil:
+			para = &span{k, e}
+		default:
+			para.end = e
+		}
+		k = e + 1
+	}
+	flush()
+
+	return nodes
+}
+
+// lineEnd returns the offset of the newline ending the line starting at offset k outside of
+// nested constructs, or j if the line is the last one.
+func (p *parser) lineEnd(k, j int) int {
+	if e := p.indexTop(k, j, "\n"); e >= 0 {
+		return e
+	}
+	return j
+}
+
+func isHeading(line string) bool {
+	return len(line) > 2 && line[0] == '=' && line[len(line)-1] == '=' && len(strings.Trim(line, "=")) > 0
+}
+
+// trimTrailingComments returns the line without the comments ending it, e.g. "== History == <!-- note -->".
+func trimTrailingComments(line string) string {
+	for strings.HasSuffix(line, "-->") {
+		i := strings.LastIndex(line, "<!--")
+		if i < 0 {
+			break
+		}
+		line = strings.TrimSpace(line[:i])
+	}
+	return line
+}
+
+func (p *parser) parseHeading(i, j int) *Heading {
+	line := p.src[i:j]
+	left := len(line) - len(strings.TrimLeft(line, "="))
+	right := len(line) - len(strings.TrimRight(line, "="))
+	level := min(left, right, 6)
+	return &Heading{
+		Level: level,
+		Title: p.parseInline(p.trim(i+level, j-level)),
+	}
+}
+
+// parseList parses the list items of the lines starting at offset k, and returns the list with
+// the offset of the newline ending its last item.
+func (p *parser) parseList(k, j int) (*List, int) {
+	list := new(List)
+	e := k - 1
+	for k < j {
+		end := p.lineEnd(k, j)
+		line := p.src[k:end]
+		if len(line) == 0 || !strings.ContainsAny(line[:1], "*#:;") {
+			break
+		}
+		marker := line[:len(line)-len(strings.TrimLeft(line, "*#:;"))]
+		list.Items = append(list.Items, &ListItem{
+			Marker: marker,
+			Nodes:  p.parseInline(p.trim(k+len(marker), end)),
+		})
+		e, k = end, end+1
+	}
+	return list, e
+}
+
+// tableEnd returns the offset of the end of the table starting at the line at offset k, i.e. the
+// end of its closing line. The ends of the nested tables are remembered on the way.
+func (p *parser) tableEnd(k, j int) int {
+	if end, ok := p.tableEnds[k]; ok {
+		return min(end, j)
+	}
+
+	var starts []int
+	for l := k; l < j; {
+		e := p.lineEnd(l, j)
+		trimmed := strings.TrimSpace(p.src[l:e])
+		switch {
+		case strings.HasPrefix(trimmed, "{|"):
+			starts = append(starts, l)
+		case strings.HasPrefix(trimmed, "|}"):
+			p.tableEnds[starts[len(starts)-1]] = e
+			if starts = starts[:len(starts)-1]; len(starts) == 0 {
+				return e
+			}
+		}
+		l = e + 1
+	}
+	for _, start := range starts {
+		p.tableEnds[start] = j
+	}
+	return j
+}
+
+// tableParser accumulates the rows and cells of a table line by line.
+type tableParser struct {
+	p     *parser
+	t     *Table
+	row   *TableRow
+	cell  *TableCell
+	cells map[*TableCell]*span // the source of each cell
+}
+
+func (p *parser) parseTable(i, j int) *Table {
+	e := p.lineEnd(i, j)
+	tp := &tableParser{
+		p:     p,
+		t:     &Table{Attrs: strings.TrimSpace(strings.TrimSpace(p.src[i:e])[2:])},
+		cells: make(map[*TableCell]*span),
+	}
+	for k := e + 1; k < j; {
+		k = tp.parseLine(k, p.lineEnd(k, j), j) + 1
+	}
+
+	for _, r := range tp.t.Rows {
+		for _, c := range r.Cells {
+			start, end := p.trim(tp.cells[c].start, tp.cells[c].end)
+			if strings.Contains(p.src[start:end], "\n") {
+				c.Nodes = p.parseBlocks(start, end)
+			} else {
+				c.Nodes = p.parseInline(start, end)
+			}
+		}
+	}
+
+	return tp.t
+}
+
+// parseLine parses the line [k, e) of a table ending at offset j, and returns the offset of the
+// newline ending the line, or the nested table starting with it.
+func (tp *tableParser) parseLine(k, e, j int) int {
+	start, end := tp.p.trim(k, e)
+	trimmed := tp.p.src[start:end]
+	switch {
+	case strings.HasPrefix(trimmed, "{|"):
+		e = tp.p.tableEnd(k, j)
+		if tp.cell != nil {
+			tp.cells[tp.cell].end = e
+		}
+	case strings.HasPrefix(trimmed, "|}"):
+	case strings.HasPrefix(trimmed, "|+"):
+		tp.t.Caption = tp.p.parseInline(tp.p.trim(start+2, end))
+	case strings.HasPrefix(trimmed, "|-"):
+		tp.row = &TableRow{Attrs: strings.TrimSpace(strings.TrimLeft(trimmed, "|-"))}
+		tp.t.Rows = append(tp.t.Rows, tp.row)
+		tp.cell = nil
+	case strings.HasPrefix(trimmed, "!"):
+		tp.addCells(start+1, end, true)
+	case strings.HasPrefix(trimmed, "|"):
+		tp.addCells(start+1, end, false)
+	case tp.cell != nil:
+		tp.cells[tp.cell].end = e
+	}
+	return e
+}
+
+func (tp *tableParser) addCells(i, j int, header bool) {
+	if tp.row == nil {
+		tp.row = new(TableRow)
+		tp.t.Rows = append(tp.t.Rows, tp.row)
+	}
+	seps := []string{"||"}
+	if header {
+		seps = append(seps, "!!")
+	}
+	for _, raw := range tp.p.splitTop(i, j, seps...) {
+		tp.cell = &TableCell{Header: header}
+		tp.cells[tp.cell] = &span{raw.start, raw.end}
+		if k := tp.p.indexTop(raw.start, raw.end, "|"); k >= 0 && !strings.HasPrefix(tp.p.src[k+1:raw.end], "|") {
+			tp.cell.Attrs = strings.TrimSpace(tp.p.src[raw.start:k])
+			tp.cells[tp.cell].start = k + 1
+		}
+		tp.row.Cells = append(tp.row.Cells, tp.cell)
+	}
+}
+
+func (p *parser) parseInline(i, j int) []Node {
+	var (
+		nodes []Node
+		text  strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &Text{Value: text.String()})
+			text.Reset()
+		}
+	}
+
+	for k := i; k < j; {
+		p.visits++
+		rest := p.src[k:j]
+		end := p.end(k, j)
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			if end < 0 {
+				end = j
+			}
+			flush()
+			nodes = append(nodes, &Comment{Value: strings.TrimSuffix(p.src[k+len("<!--"):end], "-->")})
+		case hasPrefixFold(rest, "<nowiki>") && end > 0:
+			text.WriteString(p.src[k+len("<nowiki>") : nextMatch(p.nowikis, k+len("<nowiki>"), end)[0]])
+		case hasPrefixFold(rest, "<nowiki/>"), hasPrefixFold(rest, "<nowiki />"):
+			end = k + strings.Index(rest, ">") + 1
+		case strings.HasPrefix(rest, "{{{") && end > 0:
+			text.WriteString(p.src[k:end])
+		default:
+			n, next := p.parseNode(k, j, end)
+			if n == nil {
+				text.WriteByte(p.src[k])
+				k++
+				continue
+			}
+			flush()
+			nodes = append(nodes, n)
+			end = next
+		}
+		k = end
+	}
+	flush()
+
+	return nodes
+}
+
+// parseNode parses the template, link or ref starting at offset i and ending at the given end,
+// or the external link starting at offset i, and returns it with the offset following it. It
+// returns nil if there is no such node.
+func (p *parser) parseNode(i, j, end int) (Node, int) {
+	rest := p.src[i:j]
+	switch {
+	case end < 0:
+	case strings.HasPrefix(rest, "{{"):
+		return p.parseTemplate(i+2, end-2), end
+	case strings.HasPrefix(rest, "[["):
+		return p.parseLink(i+2, end-2), end
+	case p.refOpens[i] != nil:
+		return p.parseRef(i, end), end
+	}
+	if rest[0] == '[' && extLinkRegexp.MatchString(rest) {
+		if k := sort.SearchInts(p.linkEnds, i); k < len(p.linkEnds) && p.linkEnds[k] < j && p.src[p.linkEnds[k]] == ']' {
+			return p.parseExternalLink(i+1, p.linkEnds[k]), p.linkEnds[k] + 1
+		}
+	}
+	return nil, 0
+}
+
+func (p *parser) parseTemplate(i, j int) *Template {
+	parts := p.splitTop(i, j, "|")
+	t := &Template{Name: strings.TrimSpace(stripComments(p.src[parts[0].start:parts[0].end]))}
+
+	position := 0
+	for _, part := range parts[1:] {
+		if eq := p.indexTop(part.start, part.end, "="); eq >= 0 {
+			t.Params = append(t.Params, &Param{
+				Name:  strings.TrimSpace(stripComments(p.src[part.start:eq])),
+				Value: p.parseInline(p.trim(eq+1, part.end)),
+			})
+			continue
+		}
+		position++
+		t.Params = append(t.Params, &Param{
+			Name:       strconv.Itoa(position),
+			Positional: true,
+			Value:      p.parseInline(part.start, part.end),
+		})
+	}
+
+	return t
+}
+
+func (p *parser) parseLink(i, j int) Node {
+	target, label := span{i, j}, span{j, j}
+	if k := p.indexTop(i, j, "|"); k >= 0 {
+		target, label = span{i, k}, span{k + 1, j}
+	}
+	title := strings.TrimSpace(p.src[target.start:target.end])
+
+	if filePrefixRegexp.MatchString(title) {
+		f := &File{Title: title}
+		var options []span
+		if label.end > label.start {
+			options = p.splitTop(label.start, label.end, "|")
+		}
+		for n, opt := range options {
+			if n == len(options)-1 && !isFileOption(p.src[opt.start:opt.end]) {
+				f.Caption = p.parseInline(p.trim(opt.start, opt.end))
+				break
+			}
+			f.Options = append(f.Options, strings.TrimSpace(p.src[opt.start:opt.end]))
+		}
+		return f
+	}
+
+	l := &InternalLink{Title: strings.TrimPrefix(title, ":")}
+	if k := strings.Index(l.Title, "#"); k >= 0 {
+		l.Title, l.Fragment = strings.TrimSpace(l.Title[:k]), l.Title[k+1:]
+	}
+	if label.end > label.start {
+		l.Text = p.parseInline(label.start, label.end)
+	}
+	return l
+}
+
+func isFileOption(opt string) bool {
+	opt = strings.TrimSpace(opt)
+	if fileOptions[strings.ToLower(opt)] || sizeOptionRegexp.MatchString(opt) {
+		return true
+	}
+	if i := strings.Index(opt, "="); i > 0 {
+		switch strings.ToLower(strings.TrimSpace(opt[:i])) {
+		case "alt", "link", "page", "class", "lang", "upright", "thumb", "thumbnail":
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseExternalLink(i, j int) *ExternalLink {
+	i, j = p.trim(i, j)
+	l := &ExternalLink{URL: p.src[i:j]}
+	if k := strings.IndexAny(l.URL, " \t"); k > 0 {
+		l.URL = p.src[i : i+k]
+		l.Text = p.parseInline(p.trim(i+k+1, j))
+	}
+	return l
+}
+
+// parseRef parses the ref starting at offset i and ending at offset j.
+func (p *parser) parseRef(i, j int) *Ref {
+	m := p.refOpens[i]
+	ref := new(Ref)
+	if m[2] >= 0 {
+		for _, a := range attrRegexp.FindAllStringSubmatch(p.src[m[2]:m[3]], -1) {
+			v := a[2] + a[3] + a[4]
+			switch strings.ToLower(a[1]) {
+			case "name":
+				ref.Name = v
+			case "group":
+				ref.Group = v
+			}
+		}
+	}
+	if m[5] > m[4] {
+		ref.SelfClosing = true
+		return ref
+	}
+
+	c := nextMatch(p.refCloses, m[1], j)
+	ref.Content = p.parseInline(p.trim(m[1], c[0]))
+	return ref
+}
+
+// trim returns the span [i, j) without its leading and trailing white space.
+func (p *parser) trim(i, j int) (int, int) {
+	s := p.src[i:j]
+	left := strings.TrimLeftFunc(s, unicode.IsSpace)
+	i += len(s) - len(left)
+	return i, i + len(strings.TrimRightFunc(left, unicode.IsSpace))
+}
+
+func stripComments(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "<!--")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		j := strings.Index(s[i:], "-->")
+		if j < 0 {
+			return b.String()
+		}
+		s = s[i+j+len("-->"):]
+	}
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// nextMatch returns the first of the sorted matches starting from offset i and ending by
+// offset j, or nil if there is none.
+func nextMatch(matches [][]int, i, j int) []int {
+	k := sort.Search(len(matches), func(k int) bool { return matches[k][0] >= i })
+	if k < len(matches) && matches[k][1] <= j {
+		return matches[k]
+	}
+	return nil
+}
+
+// end returns the offset following the end of the nested construct starting at offset i,
+// or -1 if there is no such construct or it is not closed by offset j.
+func (p *parser) end(i, j int) int {
+	if p.ends[i] == unknownEnd {
+		p.ends[i] = p.matchEnd(i)
+	}
+	if p.ends[i] > j {
+		return -1
+	}
+	return p.ends[i]
+}
+
+func (p *parser) matchEnd(i int) int {
+	rest := p.src[i:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		if m := nextMatch(p.comments, i+len("<!--"), len(p.src)); m != nil {
+			return m[1]
+		}
+	case hasPrefixFold(rest, "<nowiki>"):
+		if m := nextMatch(p.nowikis, i+len("<nowiki>"), len(p.src)); m != nil {
+			return m[1]
+		}
+	case strings.HasPrefix(rest, "{{{"):
+		if j := p.scanClose(i+3, "}}}"); j >= 0 {
+			return j
+		}
+		return p.scanClose(i+2, "}}")
+	case strings.HasPrefix(rest, "{{"):
+		return p.scanClose(i+2, "}}")
+	case strings.HasPrefix(rest, "[["):
+		return p.scanClose(i+2, "]]")
+	case p.refOpens[i] != nil:
+		m := p.refOpens[i]
+		if m[5] > m[4] {
+			return m[1]
+		}
+		if c := nextMatch(p.refCloses, m[1], len(p.src)); c != nil {
+			return c[1]
+		}
+	}
+	return -1
+}
+
+// scanClose returns the offset following the first closer found from offset j outside of
+// nested constructs, or -1 if there is none. The result is remembered for every offset on the
+// way, so that unclosed openers are scanned once rather than from every later opener.
+func (p *parser) scanClose(j int, closer string) int {
+	found, ok := p.closes[closer]
+	if !ok {
+		found = newEnds(len(p.src))
+		p.closes[closer] = found
+	}
+
+	var (
+		visited []int
+		res     = -1
+	)
+	for j < len(p.src) {
+		if found[j] != unknownEnd {
+			res = found[j]
+			break
+		}
+		p.visits++
+		visited = append(visited, j)
+		if strings.HasPrefix(p.src[j:], closer) {
+			res = j + len(closer)
+			break
+		}
+		if k := p.end(j, len(p.src)); k > 0 {
+			j = k
+			continue
+		}
+		j++
+	}
+	for _, v := range visited {
+		found[v] = res
+	}
+	return res
+}
+
+func newEnds(n int) []int {
+	ends := make([]int, n)
+	for i := range ends {
+		ends[i] = unknownEnd
+	}
+	return ends
+}
+
+// splitTop splits the span [i, j) around the separator occurrences outside of nested constructs.
+func (p *parser) splitTop(i, j int, seps ...string) []span {
+	var (
+		parts []span
+		start = i
+	)
+	for k := i; k < j; {
+		p.visits++
+		if e := p.end(k, j); e > 0 {
+			k = e
+			continue
+		}
+		matched := false
+		for _, sep := range seps {
+			if strings.HasPrefix(p.src[k:j], sep) {
+				parts = append(parts, span{start, k})
+				k += len(sep)
+				start = k
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			k++
+		}
+	}
+	return append(parts, span{start, j})
+}
+
+// indexTop returns the offset of the first separator occurrence of the span [i, j) outside of
+// nested constructs, or -1.
+func (p *parser) indexTop(i, j int, sep string) int {
+	for k := i; k < j; {
+		p.visits++
+		if e := p.end(k, j); e > 0 {
+			k = e
+			continue
+		}
+		if strings.HasPrefix(p.src[k:j], sep) {
+			return k
+		}
+		k++
+	}
+	return -1
+}
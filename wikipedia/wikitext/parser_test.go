@@ -0,0 +1,232 @@
+package wikitext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	src := `{{Short description|44th U.S. president}}
+{{Infobox officeholder
+| name        = Barack Obama
+| image       = President Barack Obama.jpg <!-- official portrait -->
+| birth_place = [[Honolulu]], [[Hawaii]], U.S.
+| spouse      = {{marriage|[[Michelle Obama|Michelle Robinson]]|1992}}
+}}
+
+'''Barack Hussein Obama II''' is an [[Politics of the United States|American]] politician.<ref name="bio">{{cite web
+ |url=https://www.whitehouse.gov/obama |title=Biography}}</ref> He was re-elected.<ref name="bio" />
+
+== Early life ==
+[[File:Obama 1980.jpg|thumb|upright|Obama in [[1980]]]]
+See [https://example.org the archive] and [[#Presidency|below]].
+
+=== Education ===
+* [[Punahou School]]
+*# [[Occidental College]]
+: indented
+
+{| class="wikitable"
+|+ Results
+! Year !! Votes
+|-
+| 2008 || style="color:red" | 69,498,516
+|}
+----
+[[Category:Living people]]`
+
+	doc := Parse(src)
+	require.Len(t, doc.Nodes, 9)
+
+	p := doc.Nodes[0].(*Paragraph)
+	short := p.Nodes[0].(*Template)
+	require.Equal(t, "Short description", short.Name)
+	require.Equal(
+		t,
+		[]*Param{{Name: "1", Positional: true, Value: []Node{&Text{Value: "44th U.S. president"}}}},
+		short.Params,
+	)
+
+	infobox := p.Nodes[2].(*Template)
+	require.Equal(t, "Infobox officeholder", infobox.Name)
+	require.Len(t, infobox.Params, 4)
+	image, ok := infobox.Param("IMAGE")
+	require.True(t, ok)
+	require.Equal(
+		t,
+		[]Node{&Text{Value: "President Barack Obama.jpg "}, &Comment{Value: " official portrait "}},
+		image.Value,
+	)
+	birth, _ := infobox.Param("birth_place")
+	require.Equal(t, "Honolulu, Hawaii, U.S.", PlainText(birth.Value))
+	spouse, _ := infobox.Param("spouse")
+	marriage := spouse.Value[0].(*Template)
+	require.Equal(t, "marriage", marriage.Name)
+	require.Equal(
+		t,
+		&InternalLink{Title: "Michelle Obama", Text: []Node{&Text{Value: "Michelle Robinson"}}},
+		marriage.Params[0].Value[0],
+	)
+
+	lead := doc.Nodes[1].(*Paragraph)
+	require.Equal(t, "Barack Hussein Obama II is an American politician. He was re-elected.", PlainText(lead.Nodes))
+	refs := FindAll[*Ref](lead.Nodes)
+	require.Len(t, refs, 2)
+	require.Equal(t, "bio", refs[0].Name)
+	require.False(t, refs[0].SelfClosing)
+	cite := refs[0].Content[0].(*Template)
+	require.Equal(t, "cite web", cite.Name)
+	url, _ := cite.Param("url")
+	require.Equal(t, "https://www.whitehouse.gov/obama", PlainText(url.Value))
+	require.Equal(t, &Ref{Name: "bio", SelfClosing: true}, refs[1])
+
+	require.Equal(t, &Heading{Level: 2, Title: []Node{&Text{Value: "Early life"}}}, doc.Nodes[2])
+
+	early := doc.Nodes[3].(*Paragraph)
+	require.Equal(
+		t,
+		&File{
+			Title:   "File:Obama 1980.jpg",
+			Options: []string{"thumb", "upright"},
+			Caption: []Node{&Text{Value: "Obama in "}, &InternalLink{Title: "1980"}},
+		},
+		early.Nodes[0],
+	)
+	require.Equal(t, &ExternalLink{URL: "https://example.org", Text: []Node{&Text{Value: "the archive"}}}, early.Nodes[2])
+	require.Equal(t, &InternalLink{Fragment: "Presidency", Text: []Node{&Text{Value: "below"}}}, early.Nodes[4])
+
+	require.Equal(t, 3, doc.Nodes[4].(*Heading).Level)
+
+	list := doc.Nodes[5].(*List)
+	require.Equal(t, []string{"*", "*#", ":"}, []string{list.Items[0].Marker, list.Items[1].Marker, list.Items[2].Marker})
+	require.Equal(t, []Node{&InternalLink{Title: "Occidental College"}}, list.Items[1].Nodes)
+
+	table := doc.Nodes[6].(*Table)
+	require.Equal(t, `class="wikitable"`, table.Attrs)
+	require.Equal(t, []Node{&Text{Value: "Results"}}, table.Caption)
+	require.Len(t, table.Rows, 2)
+	require.Equal(
+		t,
+		[]*TableCell{
+			{Header: true, Nodes: []Node{&Text{Value: "Year"}}},
+			{Header: true, Nodes: []Node{&Text{Value: "Votes"}}},
+		},
+		table.Rows[0].Cells,
+	)
+	require.Equal(
+		t,
+		[]*TableCell{
+			{Nodes: []Node{&Text{Value: "2008"}}},
+			{Attrs: `style="color:red"`, Nodes: []Node{&Text{Value: "69,498,516"}}},
+		},
+		table.Rows[1].Cells,
+	)
+
+	require.Equal(t, &HorizontalRule{}, doc.Nodes[7])
+	require.Equal(t, []Node{&InternalLink{Title: "Category:Living people"}}, doc.Nodes[8].(*Paragraph).Nodes)
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Node
+	}{
+		{
+			name: "unclosed template",
+			src:  "{{cite web|url=x",
+			want: []Node{&Paragraph{Nodes: []Node{&Text{Value: "{{cite web|url=x"}}}},
+		},
+		{
+			name: "unclosed link",
+			src:  "[[Honolulu",
+			want: []Node{&Paragraph{Nodes: []Node{&Text{Value: "[[Honolulu"}}}},
+		},
+		{
+			name: "unclosed comment",
+			src:  "text<!-- comment",
+			want: []Node{&Paragraph{Nodes: []Node{&Text{Value: "text"}, &Comment{Value: " comment"}}}},
+		},
+		{
+			name: "nowiki",
+			src:  "<nowiki>[[not a link]]</nowiki>",
+			want: []Node{&Paragraph{Nodes: []Node{&Text{Value: "[[not a link]]"}}}},
+		},
+		{
+			name: "heading with a trailing comment",
+			src:  "== History == <!-- note -->",
+			want: []Node{&Heading{Level: 2, Title: []Node{&Text{Value: "History"}}}},
+		},
+		{
+			name: "template argument",
+			src:  "{{{1|default}}}",
+			want: []Node{&Paragraph{Nodes: []Node{&Text{Value: "{{{1|default}}}"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Parse(tt.src).Nodes)
+		})
+	}
+}
+
+func TestParse_UnclosedOpeners(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // the plain text of the parsed source, if checked
+	}{
+		{name: "links", src: strings.Repeat("[", 5000)},
+		{name: "templates", src: strings.Repeat("{{", 5000)},
+		{name: "template arguments", src: strings.Repeat("{{{", 5000) + "x"},
+		{name: "mixed", src: strings.Repeat("[[a|{{b|<!--c", 2000)},
+		{name: "refs", src: strings.Repeat("<ref>{{cite|", 2000)},
+		{name: "ref tags", src: strings.Repeat("<ref>", 20000)},
+		{name: "ref tags with attributes", src: strings.Repeat("<ref name=a ", 20000)},
+		{name: "nowiki", src: strings.Repeat("<nowiki>", 20000)},
+		{name: "comments", src: strings.Repeat("<!--", 20000)},
+		{name: "external links", src: strings.Repeat("[https://example.org ", 20000)},
+		{name: "nested templates", src: strings.Repeat("{{a|", 4000) + strings.Repeat("}}", 4000)},
+		{name: "nested links", src: strings.Repeat("[[a|", 4000) + strings.Repeat("]]", 4000)},
+		{name: "nested tables", src: strings.Repeat("{|\n|\n", 4000) + strings.Repeat("|}\n", 4000)},
+		{
+			name: "nowiki with a letter shorter in lower case",
+			src:  "<nowiki>" + strings.Repeat("Ⱥ", 20) + "</nowiki> tail",
+			want: strings.Repeat("Ⱥ", 20) + " tail",
+		},
+		{
+			name: "nowiki with a letter longer in lower case",
+			src:  "<nowiki>" + strings.Repeat("İ", 5) + "</nowiki> tail",
+			want: strings.Repeat("İ", 5) + " tail",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newParser(tt.src)
+			nodes := p.parseBlocks(0, len(p.src))
+			require.NotEmpty(t, nodes)
+			require.LessOrEqual(t, p.visits, 8*len(tt.src), "the source is scanned more than linearly")
+			if len(tt.want) > 0 {
+				require.Equal(t, tt.want, PlainText(nodes))
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	doc := Parse(`{{Infobox|birth_place=[[Honolulu]]}}
+* [[Chicago]]
+{|
+|+ [[Caption]]
+|-
+| [[Cell]] || {{flag|[[Kenya]]}}
+|}`)
+	links := FindAll[*InternalLink](doc.Nodes)
+	titles := make([]string, 0, len(links))
+	for _, l := range links {
+		titles = append(titles, l.Title)
+	}
+	require.Equal(t, []string{"Honolulu", "Chicago", "Caption", "Cell", "Kenya"}, titles)
+}
@@ -0,0 +1,262 @@
+// Package wikitext parses MediaWiki wikitext, as returned by the revisions API,
+// into an abstract syntax tree.
+// Wikitext syntax docs: https://www.mediawiki.org/wiki/Help:Formatting
+package wikitext
+
+import (
+	"strings"
+)
+
+// Node is a node of the wikitext syntax tree.
+type Node interface {
+	children() []Node // the child nodes in document order
+}
+
+// Document is the root of a parsed wikitext.
+type Document struct {
+	Nodes []Node // the block nodes
+}
+
+// Heading is a section heading, e.g. "== History ==".
+type Heading struct {
+	Level int    // the number of "=" of the heading, 2 for "== History =="
+	Title []Node // the inline nodes of the heading
+}
+
+// Paragraph is a run of consecutive text lines.
+type Paragraph struct {
+	Nodes []Node // the inline nodes
+}
+
+// List is a run of consecutive list items.
+type List struct {
+	Items []*ListItem
+}
+
+// ListItem is a line of a list, e.g. "* item" or "#: continuation".
+type ListItem struct {
+	Marker string // the list markers of the line, e.g. "*", "#" or "*#"
+	Nodes  []Node // the inline nodes
+}
+
+// HorizontalRule is a "----" line.
+type HorizontalRule struct{}
+
+// Table is a "{| ... |}" table.
+type Table struct {
+	Attrs   string // the raw HTML attributes of the table
+	Caption []Node // the inline nodes of the "|+" caption
+	Rows    []*TableRow
+}
+
+// TableRow is a row of a table.
+type TableRow struct {
+	Attrs string // the raw HTML attributes of the "|-" line
+	Cells []*TableCell
+}
+
+// TableCell is a cell of a table row.
+type TableCell struct {
+	Header bool   // whether the cell is a "!" header cell
+	Attrs  string // the raw HTML attributes of the cell, e.g. `colspan="2"`
+	Nodes  []Node // the inline nodes, or the block nodes of a multiline cell
+}
+
+// Text is a run of plain text, including the bold and italic quote markup.
+type Text struct {
+	Value string
+}
+
+// InternalLink is a link to a wiki page, e.g. "[[Honolulu|Hawaii]]".
+type InternalLink struct {
+	Title    string // the target page title, without the leading colon of a colon link
+	Fragment string // the section anchor of the target, if any
+	Text     []Node // the inline nodes of the label, empty when the title is displayed
+}
+
+// ExternalLink is a bracketed link to an URL, e.g. "[https://example.org Example]".
+type ExternalLink struct {
+	URL  string
+	Text []Node // the inline nodes of the label, if any
+}
+
+// Template is a template transclusion or a parser function, e.g. "{{cite web|url=...}}".
+type Template struct {
+	Name   string // the template name as written, e.g. "Infobox person" or "#if:"
+	Params []*Param
+}
+
+// Param is a parameter of a template.
+type Param struct {
+	Name       string // the parameter name, the 1-based position for a positional parameter
+	Positional bool   // whether the parameter is positional
+	Value      []Node // the inline nodes of the value, trimmed for a named parameter
+}
+
+// File is an embedded file, e.g. "[[File:Obama.jpg|thumb|Obama in 2012]]".
+type File struct {
+	Title   string   // the file title, with its namespace prefix
+	Options []string // the raw display options, e.g. "thumb" or "220px"
+	Caption []Node   // the inline nodes of the caption, if any
+}
+
+// Ref is a "<ref>" footnote.
+type Ref struct {
+	Name        string // the name attribute, if any
+	Group       string // the group attribute, if any
+	SelfClosing bool   // whether the ref is a "<ref name=... />" reuse
+	Content     []Node // the inline nodes of the footnote
+}
+
+// Comment is a "<!-- ... -->" HTML comment.
+type Comment struct {
+	Value string
+}
+
+func (d *Document) children() []Node     { return d.Nodes }
+func (h *Heading) children() []Node      { return h.Title }
+func (p *Paragraph) children() []Node    { return p.Nodes }
+func (*HorizontalRule) children() []Node { return nil }
+func (i *ListItem) children() []Node     { return i.Nodes }
+func (c *TableCell) children() []Node    { return c.Nodes }
+func (*Text) children() []Node           { return nil }
+func (l *InternalLink) children() []Node { return l.Text }
+func (l *ExternalLink) children() []Node { return l.Text }
+func (p *Param) children() []Node        { return p.Value }
+func (f *File) children() []Node         { return f.Caption }
+func (r *Ref) children() []Node          { return r.Content }
+func (*Comment) children() []Node        { return nil }
+
+func (l *List) children() []Node {
+	nodes := make([]Node, 0, len(l.Items))
+	for _, item := range l.Items {
+		nodes = append(nodes, item)
+	}
+	return nodes
+}
+
+func (t *Table) children() []Node {
+	nodes := append([]Node(nil), t.Caption...)
+	for _, row := range t.Rows {
+		nodes = append(nodes, row)
+	}
+	return nodes
+}
+
+func (r *TableRow) children() []Node {
+	nodes := make([]Node, 0, len(r.Cells))
+	for _, cell := range r.Cells {
+		nodes = append(nodes, cell)
+	}
+	return nodes
+}
+
+func (t *Template) children() []Node {
+	nodes := make([]Node, 0, len(t.Params))
+	for _, p := range t.Params {
+		nodes = append(nodes, p)
+	}
+	return nodes
+}
+
+// Param returns the parameter of the template with the given name. The name is
+// matched case-insensitively, as "1" for the first positional parameter.
+func (t *Template) Param(name string) (*Param, bool) {
+	for i := len(t.Params) - 1; i >= 0; i-- { // the last value wins
+		if strings.EqualFold(t.Params[i].Name, name) {
+			return t.Params[i], true
+		}
+	}
+	return nil, false
+}
+
+// Walk traverses the nodes depth-first in document order. The children of a node
+// are skipped if fn returns false.
+func Walk(nodes []Node, fn func(Node) bool) {
+	for _, n := range nodes {
+		if fn(n) {
+			Walk(n.children(), fn)
+		}
+	}
+}
+
+// FindAll returns all the nodes of type T in document order, including the nested ones.
+func FindAll[T Node](nodes []Node) []T {
+	var found []T
+	Walk(nodes, func(n Node) bool {
+		if v, ok := n.(T); ok {
+			found = append(found, v)
+		}
+		return true
+	})
+	return found
+}
+
+var quoteReplacer = strings.NewReplacer("'''''", "", "'''", "", "''", "")
+
+// PlainText returns the text of the nodes as it would be displayed, without templates,
+// footnotes, files, comments and formatting.
+func PlainText(nodes []Node) string {
+	var b strings.Builder
+	writePlainText(&b, nodes)
+	return strings.TrimSpace(b.String())
+}
+
+func writePlainText(b *strings.Builder, nodes []Node) {
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case *Document:
+			writePlainText(b, v.Nodes)
+		case *Heading:
+			writePlainText(b, v.Title)
+			b.WriteString("\n")
+		case *Paragraph:
+			writePlainText(b, v.Nodes)
+			b.WriteString("\n")
+		case *List:
+			for _, item := range v.Items {
+				writePlainText(b, item.Nodes)
+				b.WriteString("\n")
+			}
+		case *ListItem:
+			writePlainText(b, v.Nodes)
+		case *Table:
+			writeTablePlainText(b, v)
+		case *TableCell:
+			writePlainText(b, v.Nodes)
+		case *Text:
+			b.WriteString(quoteReplacer.Replace(v.Value))
+		case *InternalLink:
+			writeLinkPlainText(b, v)
+		case *ExternalLink:
+			writePlainText(b, v.Text)
+		case *Param:
+			writePlainText(b, v.Value)
+		}
+	}
+}
+
+// writeTablePlainText writes the table a row per line, with the cells separated by tabs.
+func writeTablePlainText(b *strings.Builder, t *Table) {
+	for _, row := range t.Rows {
+		for i, cell := range row.Cells {
+			if i > 0 {
+				b.WriteString("\t")
+			}
+			b.WriteString(PlainText(cell.Nodes))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeLinkPlainText writes the label of the link, or its title if it has none. The category
+// links are not displayed.
+func writeLinkPlainText(b *strings.Builder, l *InternalLink) {
+	switch {
+	case strings.HasPrefix(strings.ToLower(l.Title), "category:"):
+	case len(l.Text) > 0:
+		writePlainText(b, l.Text)
+	default:
+		b.WriteString(l.Title)
+	}
+}
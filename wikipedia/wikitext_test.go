@@ -0,0 +1,95 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/majdus/go-wikipedia/internal/testhelper"
+)
+
+func TestClient_GetWikitextByRevision(t *testing.T) {
+	ts := testhelper.NewTestHTTPServer()
+	ts.RegisterHandler("/w/api.php", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			panic(err)
+		}
+		for k, v := range map[string]string{
+			"prop":          "revisions",
+			"revids":        "1165884406",
+			"rvprop":        "ids|flags|timestamp|user|size|sha1|comment|content",
+			"rvslots":       "main",
+			"formatversion": "2",
+		} {
+			if !checkQuery(r.Form, k, v) {
+				http.Error(w, "invalid "+k, http.StatusBadRequest)
+				return
+			}
+		}
+		fmt.Fprint(w, `
+{
+    "batchcomplete": true,
+    "query": {
+        "pages": [
+            {
+                "pageid": 534366,
+                "ns": 0,
+                "title": "Barack Obama",
+                "revisions": [
+                    {
+                        "revid": 1165884406,
+                        "parentid": 1165765677,
+                        "minor": true,
+                        "user": "Editor",
+                        "timestamp": "2023-07-18T16:26:29Z",
+                        "size": 346245,
+                        "sha1": "0a1b2c",
+                        "comment": "copyedit",
+                        "slots": {
+                            "main": {
+                                "contentmodel": "wikitext",
+                                "contentformat": "text/x-wiki",
+                                "content": "'''Barack Hussein Obama II''' is an [[United States|American]] politician."
+                            }
+                        }
+                    }
+                ]
+            }
+        ]
+    }
+}`)
+	})
+
+	ts.Start()
+	defer ts.Stop()
+
+	c, err := NewClient()
+	require.NoError(t, err)
+
+	c.url = ts.URL() + "/w/api.php"
+	got, err := c.GetWikitextByRevision(context.TODO(), 1165884406)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&Wikitext{
+			PageID:       534366,
+			Title:        "Barack Obama",
+			Content:      "'''Barack Hussein Obama II''' is an [[United States|American]] politician.",
+			ContentModel: "wikitext",
+			Revision: &Revision{
+				RevisionID: 1165884406,
+				ParentID:   1165765677,
+				Minor:      true,
+				User:       "Editor",
+				Timestamp:  "2023-07-18T16:26:29Z",
+				Size:       346245,
+				SHA1:       "0a1b2c",
+				Comment:    "copyedit",
+			},
+		},
+		got,
+	)
+}